@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func parseBound(id string, boundType string) (*eventsourcingdb.Bound, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	switch eventsourcingdb.BoundType(boundType) {
+	case eventsourcingdb.BoundTypeInclusive, eventsourcingdb.BoundTypeExclusive:
+		return &eventsourcingdb.Bound{
+			ID:   id,
+			Type: eventsourcingdb.BoundType(boundType),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported bound type '%s'", boundType)
+	}
+}
+
+func getSubject(args []string) string {
+	if len(args) == 0 {
+		return "/"
+	}
+
+	return args[0]
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+// clientFlags configure the connection. If neither a URL, a connection
+// string, nor an API token is given, the client is configured from the
+// environment, see eventsourcingdb.NewClientFromEnv. An API token given as
+// flag is used with $EVENTSOURCINGDB_URL, but must not be combined with a
+// connection string, since that contains an API token itself.
+type clientFlags struct {
+	connectionString string
+	url              string
+	apiToken         string
+	apiTokenFile     string
+	timeout          time.Duration
+}
+
+func registerClientFlags(flagSet *flag.FlagSet) *clientFlags {
+	flags := &clientFlags{}
+	flagSet.StringVar(&flags.connectionString, "connection-string", "", "connection string, e.g. esdb://<api-token>@localhost:3000")
+	flagSet.StringVar(&flags.url, "url", "", "base URL of the instance (default $EVENTSOURCINGDB_URL)")
+	flagSet.StringVar(&flags.apiToken, "api-token", "", "API token (default $EVENTSOURCINGDB_API_TOKEN)")
+	flagSet.StringVar(&flags.apiTokenFile, "api-token-file", "", "file to read the API token from")
+	flagSet.DurationVar(&flags.timeout, "timeout", 0, "timeout for connecting and waiting for responses")
+
+	return flags
+}
+
+func (f *clientFlags) newClient() (*eventsourcingdb.Client, error) {
+	var options []eventsourcingdb.ClientOption
+	if f.apiTokenFile != "" {
+		options = append(options, eventsourcingdb.WithTokenProvider(eventsourcingdb.NewFileTokenProvider(f.apiTokenFile)))
+	}
+	if f.timeout > 0 {
+		options = append(options, eventsourcingdb.WithTimeout(f.timeout))
+	}
+
+	hasTokenFlag := f.apiToken != "" || f.apiTokenFile != ""
+	rawURL := f.url
+
+	switch {
+	case f.connectionString != "" && f.url != "":
+		return nil, errors.New("only one of --connection-string and --url must be given")
+	case f.connectionString != "" && hasTokenFlag:
+		return nil, errors.New("--api-token and --api-token-file must not be given with --connection-string, which contains the API token")
+	case f.connectionString != "":
+		return eventsourcingdb.NewClientFromConnectionString(f.connectionString, options...)
+	case rawURL == "" && !hasTokenFlag:
+		return eventsourcingdb.NewClientFromEnv(options...)
+	case rawURL == "":
+		// A token given as flag is used with the URL from the environment,
+		// just like a token from the environment is used with --url.
+		if os.Getenv("EVENTSOURCINGDB_CONNECTION_STRING") != "" {
+			return nil, errors.New("--api-token and --api-token-file must not be given with $EVENTSOURCINGDB_CONNECTION_STRING, which contains the API token")
+		}
+
+		rawURL = os.Getenv("EVENTSOURCINGDB_URL")
+		if rawURL == "" {
+			return nil, errors.New("either --url, --connection-string, or $EVENTSOURCINGDB_URL must be given")
+		}
+	}
+
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	apiToken := f.apiToken
+	if apiToken == "" {
+		apiToken = os.Getenv("EVENTSOURCINGDB_API_TOKEN")
+	}
+	if apiToken == "" && f.apiTokenFile == "" {
+		return nil, errors.New("either --api-token or --api-token-file must be given")
+	}
+
+	return eventsourcingdb.NewClient(baseURL, apiToken, options...)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+const (
+	auditCheckFormat          = "format"
+	auditCheckHash            = "hash"
+	auditCheckSignature       = "signature"
+	auditCheckPredecessorHash = "predecessorHash"
+	auditCheckManifest        = "manifest"
+)
+
+type auditReport struct {
+	EventCount            int              `json:"eventCount"`
+	FirstEventID          string           `json:"firstEventId"`
+	LastEventID           string           `json:"lastEventId"`
+	FinalHash             string           `json:"finalHash"`
+	AreSignaturesVerified bool             `json:"areSignaturesVerified"`
+	IsValid               bool             `json:"isValid"`
+	Violations            []auditViolation `json:"violations"`
+}
+
+type auditViolation struct {
+	Line    int    `json:"line"`
+	EventID string `json:"eventId,omitempty"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// auditLine is a line of a backup. Lines of a plain export are events, which
+// have no payload.
+type auditLine struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+func runAudit(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "audit")
+	verificationKeyFile := flagSet.String("verification-key", "", "PEM file with the public key to verify signatures with")
+	skipSignatures := flagSet.Bool("skip-signatures", false, "do not verify signatures, e.g. for instances without a signing key")
+	err := parseFlags(flagSet, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	// Skipping signatures must be explicit, since a report without them would
+	// otherwise look like one that verified them.
+	var verificationKey ed25519.PublicKey
+	switch {
+	case *verificationKeyFile != "" && *skipSignatures:
+		return errors.New("only one of --verification-key and --skip-signatures must be given")
+	case *verificationKeyFile != "":
+		verificationKey, err = readVerificationKey(*verificationKeyFile)
+		if err != nil {
+			return err
+		}
+	case !*skipSignatures:
+		return errors.New("either --verification-key or --skip-signatures must be given")
+	}
+
+	file, err := os.Open(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := audit(ctx, file, verificationKey)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSON(env.stdout, report, true)
+	if err != nil {
+		return err
+	}
+
+	if !report.IsValid {
+		return fmt.Errorf("found %d violation(s)", len(report.Violations))
+	}
+
+	return nil
+}
+
+func readVerificationKey(path string) (ed25519.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("verification key must be a PEM-encoded public key")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKey, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("verification key must be an Ed25519 key")
+	}
+
+	return verificationKey, nil
+}
+
+// audit checks an export of events, which is either a backup created by the
+// backup command, or NDJSON as printed by the read command. It reads the
+// export line by line, so that it can report all violations instead of
+// stopping at the first one.
+func audit(ctx context.Context, reader io.Reader, verificationKey ed25519.PublicKey) (auditReport, error) {
+	bufferedReader := bufio.NewReader(reader)
+
+	// Backups are compressed using gzip, which is recognized by its magic
+	// number.
+	magicNumber, err := bufferedReader.Peek(2)
+	if err == nil && bytes.Equal(magicNumber, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			return auditReport{}, err
+		}
+		defer gzipReader.Close()
+
+		bufferedReader = bufio.NewReader(gzipReader)
+	}
+
+	report := auditReport{
+		AreSignaturesVerified: verificationKey != nil,
+		Violations:            []auditViolation{},
+	}
+	addViolation := func(line int, eventID string, check string, message string) {
+		report.Violations = append(report.Violations, auditViolation{
+			Line:    line,
+			EventID: eventID,
+			Check:   check,
+			Message: message,
+		})
+	}
+
+	var previousEvent *eventsourcingdb.Event
+	var manifest *eventsourcingdb.BackupManifest
+	manifestLine := 0
+	isBackup := false
+	lineNumber := 0
+
+	for {
+		if ctx.Err() != nil {
+			return auditReport{}, ctx.Err()
+		}
+
+		content, err := bufferedReader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return auditReport{}, err
+		}
+		if len(content) == 0 && errors.Is(err, io.EOF) {
+			break
+		}
+
+		lineNumber++
+		content = bytes.TrimSpace(content)
+		if len(content) == 0 {
+			continue
+		}
+
+		var line auditLine
+		unmarshalErr := json.Unmarshal(content, &line)
+		if unmarshalErr != nil {
+			addViolation(lineNumber, "", auditCheckFormat, unmarshalErr.Error())
+			continue
+		}
+
+		eventPayload := content
+		if line.Payload != nil {
+			isBackup = true
+
+			switch line.Type {
+			case "event":
+				eventPayload = line.Payload
+			case "manifest":
+				manifest = &eventsourcingdb.BackupManifest{}
+				manifestLine = lineNumber
+				unmarshalErr := json.Unmarshal(line.Payload, manifest)
+				if unmarshalErr != nil {
+					addViolation(lineNumber, "", auditCheckFormat, unmarshalErr.Error())
+				}
+				continue
+			default:
+				addViolation(lineNumber, "", auditCheckFormat, fmt.Sprintf("unsupported line type '%s'", line.Type))
+				continue
+			}
+		}
+
+		if manifest != nil {
+			addViolation(lineNumber, "", auditCheckManifest, "unexpected line after the manifest")
+		}
+
+		var cloudEvent eventsourcingdb.CloudEvent
+		unmarshalErr = json.Unmarshal(eventPayload, &cloudEvent)
+		if unmarshalErr != nil {
+			addViolation(lineNumber, "", auditCheckFormat, unmarshalErr.Error())
+			continue
+		}
+
+		event, parseErr := eventsourcingdb.NewEventFromCloudEvent(cloudEvent)
+		if parseErr != nil {
+			addViolation(lineNumber, cloudEvent.ID, auditCheckFormat, parseErr.Error())
+			continue
+		}
+
+		hashErr := event.VerifyHash()
+		if hashErr != nil {
+			addViolation(lineNumber, event.ID, auditCheckHash, hashErr.Error())
+		} else if verificationKey != nil {
+			signatureErr := event.VerifySignature(verificationKey)
+			if signatureErr != nil {
+				addViolation(lineNumber, event.ID, auditCheckSignature, signatureErr.Error())
+			}
+		}
+
+		switch {
+		case previousEvent != nil && event.PredecessorHash != previousEvent.Hash:
+			addViolation(lineNumber, event.ID, auditCheckPredecessorHash, fmt.Sprintf("predecessor hash does not match the hash of event '%s'", previousEvent.ID))
+		case previousEvent == nil && event.PredecessorHash != eventsourcingdb.InitialPredecessorHash:
+			addViolation(lineNumber, event.ID, auditCheckPredecessorHash, "predecessor hash of the first event is not the initial hash, so the export does not start with the first event")
+		}
+
+		if report.EventCount == 0 {
+			report.FirstEventID = event.ID
+		}
+		report.EventCount++
+		report.LastEventID = event.ID
+		report.FinalHash = event.Hash
+		previousEvent = &event
+	}
+
+	if isBackup {
+		switch {
+		case manifest == nil:
+			addViolation(lineNumber, "", auditCheckManifest, "manifest is missing")
+		case manifest.EventCount != report.EventCount ||
+			manifest.FirstEventID != report.FirstEventID ||
+			manifest.LastEventID != report.LastEventID ||
+			manifest.FinalHash != report.FinalHash:
+			addViolation(manifestLine, "", auditCheckManifest, "manifest does not match the events")
+		}
+	}
+
+	report.IsValid = len(report.Violations) == 0
+
+	return report, nil
+}
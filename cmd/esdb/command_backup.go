@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+var manifestHeaders = []string{"EVENTS", "FIRST ID", "LAST ID", "FINAL HASH", "SCHEMAS"}
+
+func runBackup(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "backup")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeSingle, env.stdout, manifestHeaders...)
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(flagSet.Arg(0), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := eventsourcingdb.Backup(ctx, client, file)
+	if err != nil {
+		file.Close()
+		os.Remove(flagSet.Arg(0))
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	err = writeManifest(output, manifest)
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
+
+func writeManifest(output *output, manifest eventsourcingdb.BackupManifest) error {
+	return output.write(
+		manifest,
+		strconv.Itoa(manifest.EventCount),
+		manifest.FirstEventID,
+		manifest.LastEventID,
+		manifest.FinalHash,
+		strconv.Itoa(len(manifest.EventSchemas)),
+	)
+}
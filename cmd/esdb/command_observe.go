@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func runObserve(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "observe")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+
+	recursive := flagSet.Bool("recursive", false, "include events of nested subjects")
+	lowerBound := flagSet.String("lower-bound", "", "ID of the first event to observe")
+	lowerBoundType := flagSet.String("lower-bound-type", "inclusive", "type of the lower bound: inclusive or exclusive")
+	fromLatestEventSubject := flagSet.String("from-latest-event-subject", "", "start from the latest event of this subject")
+	fromLatestEventType := flagSet.String("from-latest-event-type", "", "start from the latest event of this type")
+	ifEventIsMissing := flagSet.String("if-event-is-missing", string(eventsourcingdb.ObserveEverythingIfEventIsMissing), "if the latest event is missing: read-everything or wait-for-event")
+
+	err := parseFlags(flagSet, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeStream, env.stdout, eventHeaders...)
+	if err != nil {
+		return err
+	}
+
+	options := eventsourcingdb.ObserveEventsOptions{
+		Recursive: *recursive,
+	}
+
+	options.LowerBound, err = parseBound(*lowerBound, *lowerBoundType)
+	if err != nil {
+		return err
+	}
+
+	if *fromLatestEventSubject != "" || *fromLatestEventType != "" {
+		options.FromLatestEvent = &eventsourcingdb.ObserveFromLatestEvent{
+			Subject:          *fromLatestEventSubject,
+			Type:             *fromLatestEventType,
+			IfEventIsMissing: eventsourcingdb.ObserveIfEventIsMissing(*ifEventIsMissing),
+		}
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	// Observing ends once the context is canceled, e.g. by pressing Ctrl+C,
+	// which is not an error.
+	for event, err := range client.ObserveEvents(ctx, getSubject(flagSet.Args()), options) {
+		if err != nil {
+			return err
+		}
+
+		err = writeEvent(output, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+)
+
+type pingResult struct {
+	Status        string `json:"status"`
+	ServerVersion string `json:"serverVersion"`
+}
+
+func runPing(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "ping")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeSingle, env.stdout, "STATUS", "SERVER VERSION")
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.Ping()
+	if err != nil {
+		return err
+	}
+
+	serverInfo, err := client.ServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = output.write(pingResult{Status: "ok", ServerVersion: serverInfo.RawVersion}, "ok", serverInfo.RawVersion)
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
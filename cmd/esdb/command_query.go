@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+)
+
+func runQuery(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "query")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeList, env.stdout, "ROW")
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	for row, err := range client.RunEventQLQuery(ctx, flagSet.Arg(0)) {
+		if err != nil {
+			return err
+		}
+
+		err = output.write(row, compactJSON(row))
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func runRead(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "read")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+
+	recursive := flagSet.Bool("recursive", false, "include events of nested subjects")
+	order := flagSet.String("order", "chronological", "order of events: chronological or antichronological")
+	lowerBound := flagSet.String("lower-bound", "", "ID of the first event to read")
+	lowerBoundType := flagSet.String("lower-bound-type", "inclusive", "type of the lower bound: inclusive or exclusive")
+	upperBound := flagSet.String("upper-bound", "", "ID of the last event to read")
+	upperBoundType := flagSet.String("upper-bound-type", "inclusive", "type of the upper bound: inclusive or exclusive")
+	fromLatestEventSubject := flagSet.String("from-latest-event-subject", "", "start from the latest event of this subject")
+	fromLatestEventType := flagSet.String("from-latest-event-type", "", "start from the latest event of this type")
+	ifEventIsMissing := flagSet.String("if-event-is-missing", string(eventsourcingdb.ReadEverythingIfEventIsMissing), "if the latest event is missing: read-everything or read-nothing")
+
+	err := parseFlags(flagSet, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeList, env.stdout, eventHeaders...)
+	if err != nil {
+		return err
+	}
+
+	options := eventsourcingdb.ReadEventsOptions{
+		Recursive: *recursive,
+	}
+
+	switch *order {
+	case "chronological":
+		options.Order = eventsourcingdb.OrderChronological()
+	case "antichronological":
+		options.Order = eventsourcingdb.OrderAntichronological()
+	default:
+		return fmt.Errorf("unsupported order '%s'", *order)
+	}
+
+	options.LowerBound, err = parseBound(*lowerBound, *lowerBoundType)
+	if err != nil {
+		return err
+	}
+	options.UpperBound, err = parseBound(*upperBound, *upperBoundType)
+	if err != nil {
+		return err
+	}
+
+	if *fromLatestEventSubject != "" || *fromLatestEventType != "" {
+		options.FromLatestEvent = &eventsourcingdb.ReadFromLatestEvent{
+			Subject:          *fromLatestEventSubject,
+			Type:             *fromLatestEventType,
+			IfEventIsMissing: eventsourcingdb.ReadIfEventIsMissing(*ifEventIsMissing),
+		}
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	for event, err := range client.ReadEvents(ctx, getSubject(flagSet.Args()), options) {
+		if err != nil {
+			return err
+		}
+
+		err = writeEvent(output, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
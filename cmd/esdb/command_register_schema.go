@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+type registerSchemaResult struct {
+	EventType string `json:"eventType"`
+	Status    string `json:"status"`
+}
+
+func runRegisterSchema(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "register-schema")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	file := flagSet.String("file", "", "file to read the schema from (default stdin)")
+	err := parseFlags(flagSet, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeSingle, env.stdout, "EVENT TYPE", "STATUS")
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+	if *file != "" {
+		content, err = os.ReadFile(*file)
+	} else {
+		content, err = io.ReadAll(env.stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	var schema map[string]any
+	err = json.Unmarshal(content, &schema)
+	if err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	eventType := flagSet.Arg(0)
+	err = client.RegisterEventSchema(eventType, schema)
+	if err != nil {
+		return err
+	}
+
+	err = output.write(registerSchemaResult{EventType: eventType, Status: "registered"}, eventType, "registered")
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
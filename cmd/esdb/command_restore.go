@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func runRestore(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "restore")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	batchSize := flagSet.Int("batch-size", 100, "number of events to write per request")
+	err := parseFlags(flagSet, args, 1, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeSingle, env.stdout, manifestHeaders...)
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	manifest, err := eventsourcingdb.Restore(ctx, client, file, eventsourcingdb.RestoreOptions{
+		BatchSize: *batchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = writeManifest(output, manifest)
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+)
+
+func runSubjects(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "subjects")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeList, env.stdout, "SUBJECT")
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	for subject, err := range client.ReadSubjects(ctx, getSubject(flagSet.Args())) {
+		if err != nil {
+			return err
+		}
+
+		err = output.write(subject, subject)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
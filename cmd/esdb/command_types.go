@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+type eventTypeResult struct {
+	EventType string          `json:"eventType"`
+	IsPhantom bool            `json:"isPhantom"`
+	Schema    *map[string]any `json:"schema,omitempty"`
+}
+
+func runTypes(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "types")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 0, 1)
+	if err != nil {
+		return err
+	}
+
+	mode := outputModeList
+	if flagSet.NArg() == 1 {
+		mode = outputModeSingle
+	}
+
+	output, err := newOutput(*format, mode, env.stdout, "EVENT TYPE", "PHANTOM", "SCHEMA")
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	if flagSet.NArg() == 1 {
+		eventType, err := client.ReadEventType(flagSet.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		err = writeEventType(output, eventType)
+		if err != nil {
+			return err
+		}
+
+		return output.close()
+	}
+
+	for eventType, err := range client.ReadEventTypes(ctx) {
+		if err != nil {
+			return err
+		}
+
+		err = writeEventType(output, eventType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
+
+func writeEventType(output *output, eventType eventsourcingdb.EventType) error {
+	schema := ""
+	if eventType.Schema != nil {
+		rawSchema, err := json.Marshal(eventType.Schema)
+		if err != nil {
+			return err
+		}
+		schema = string(rawSchema)
+	}
+
+	return output.write(
+		eventTypeResult{
+			EventType: eventType.EventType,
+			IsPhantom: eventType.IsPhantom,
+			Schema:    eventType.Schema,
+		},
+		eventType.EventType,
+		strconv.FormatBool(eventType.IsPhantom),
+		schema,
+	)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+)
+
+type verifyTokenResult struct {
+	Status string `json:"status"`
+}
+
+func runVerifyToken(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "verify-token")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+	err := parseFlags(flagSet, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeSingle, env.stdout, "STATUS")
+	if err != nil {
+		return err
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.VerifyAPIToken()
+	if err != nil {
+		return err
+	}
+
+	err = output.write(verifyTokenResult{Status: "ok"}, "ok")
+	if err != nil {
+		return err
+	}
+
+	return output.close()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// stringsFlag collects the values of a flag that can be given multiple times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func runWrite(ctx context.Context, env *environment, args []string) error {
+	flagSet := newFlagSet(env, "write")
+	clientFlags := registerClientFlags(flagSet)
+	format := registerOutputFlag(flagSet)
+
+	var isSubjectPristine, isSubjectPopulated, isSubjectOnEventID, isEventQLQueryTrue stringsFlag
+	flagSet.Var(&isSubjectPristine, "is-subject-pristine", "require the given subject to have no events (repeatable)")
+	flagSet.Var(&isSubjectPopulated, "is-subject-populated", "require the given subject to have events (repeatable)")
+	flagSet.Var(&isSubjectOnEventID, "is-subject-on-event-id", "require the last event of a subject to have the given ID, as <subject>:<event-id> (repeatable)")
+	flagSet.Var(&isEventQLQueryTrue, "is-eventql-query-true", "require the given EventQL query to return true (repeatable)")
+
+	err := parseFlags(flagSet, args, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	output, err := newOutput(*format, outputModeList, env.stdout, eventHeaders...)
+	if err != nil {
+		return err
+	}
+
+	preconditions := []eventsourcingdb.Precondition{}
+	for _, subject := range isSubjectPristine {
+		preconditions = append(preconditions, eventsourcingdb.NewIsSubjectPristinePrecondition(subject))
+	}
+	for _, subject := range isSubjectPopulated {
+		preconditions = append(preconditions, eventsourcingdb.NewIsSubjectPopulatedPrecondition(subject))
+	}
+	for _, value := range isSubjectOnEventID {
+		separatorIndex := strings.LastIndex(value, ":")
+		if separatorIndex == -1 {
+			return fmt.Errorf("invalid value '%s' for --is-subject-on-event-id, expected <subject>:<event-id>", value)
+		}
+		preconditions = append(preconditions, eventsourcingdb.NewIsSubjectOnEventIDPrecondition(value[:separatorIndex], value[separatorIndex+1:]))
+	}
+	for _, query := range isEventQLQueryTrue {
+		preconditions = append(preconditions, eventsourcingdb.NewIsEventQLQueryTruePrecondition(query))
+	}
+
+	content, err := io.ReadAll(env.stdin)
+	if err != nil {
+		return err
+	}
+
+	eventCandidates, err := internal.UnmarshalEventCandidates(content)
+	if err != nil {
+		return fmt.Errorf("invalid events: %w", err)
+	}
+	if len(eventCandidates) == 0 {
+		return fmt.Errorf("no events given on stdin")
+	}
+
+	events := make([]eventsourcingdb.EventCandidate, 0, len(eventCandidates))
+	for _, eventCandidate := range eventCandidates {
+		events = append(events, eventsourcingdb.EventCandidate{
+			Source:      eventCandidate.Source,
+			Subject:     eventCandidate.Subject,
+			Type:        eventCandidate.Type,
+			Data:        eventCandidate.Data,
+			TraceParent: eventCandidate.TraceParent,
+			TraceState:  eventCandidate.TraceState,
+		})
+	}
+
+	client, err := clientFlags.newClient()
+	if err != nil {
+		return err
+	}
+
+	writtenEvents, err := client.WriteEvents(events, preconditions)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range writtenEvents {
+		err := writeEvent(output, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return output.close()
+}
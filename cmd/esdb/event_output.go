@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+var eventHeaders = []string{"ID", "TIME", "SUBJECT", "TYPE", "DATA"}
+
+func writeEvent(output *output, event eventsourcingdb.Event) error {
+	return output.write(
+		eventsourcingdb.NewCloudEventFromEvent(event),
+		event.ID,
+		event.Time.Format(time.RFC3339),
+		event.Subject,
+		event.Type,
+		compactJSON(event.Data),
+	)
+}
+
+func compactJSON(data json.RawMessage) string {
+	var buffer bytes.Buffer
+	err := json.Compact(&buffer, data)
+	if err != nil {
+		return string(data)
+	}
+
+	return buffer.String()
+}
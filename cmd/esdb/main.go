@@ -0,0 +1,22 @@
+// Command esdb provides everyday operations on an EventSourcingDB instance,
+// such as writing, reading, and observing events, from the command line.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := run(ctx, os.Args[1:], &environment{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	})
+	stop()
+
+	os.Exit(exitCode)
+}
@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+type commandResult struct {
+	exitCode int
+	stdout   string
+	stderr   string
+}
+
+func startServer(t *testing.T) []string {
+	t.Helper()
+
+	return startConfiguredServer(t, eventsourcingdbtest.NewServer())
+}
+
+func startConfiguredServer(t *testing.T, server *eventsourcingdbtest.Server) []string {
+	t.Helper()
+
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+
+	baseURL, err := server.GetBaseURL()
+	require.NoError(t, err)
+
+	return []string{"--url", baseURL.String(), "--api-token", server.GetAPIToken()}
+}
+
+func runCommand(stdin string, args ...string) commandResult {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	exitCode := run(context.Background(), args, &environment{
+		stdin:  strings.NewReader(stdin),
+		stdout: stdout,
+		stderr: stderr,
+	})
+
+	return commandResult{exitCode, stdout.String(), stderr.String()}
+}
+
+func withConnection(command string, connection []string, args ...string) []string {
+	return append(append([]string{command}, connection...), args...)
+}
+
+func TestRun(t *testing.T) {
+	t.Run("prints the usage and fails without a command.", func(t *testing.T) {
+		result := runCommand("")
+
+		assert.Equal(t, 2, result.exitCode)
+		assert.Contains(t, result.stderr, "Usage: esdb <command>")
+	})
+
+	t.Run("fails for an unknown command.", func(t *testing.T) {
+		result := runCommand("", "unknown")
+
+		assert.Equal(t, 2, result.exitCode)
+		assert.Contains(t, result.stderr, "unknown command 'unknown'")
+	})
+
+	t.Run("fails for an unexpected number of arguments.", func(t *testing.T) {
+		result := runCommand("", "query")
+
+		assert.Equal(t, 2, result.exitCode)
+		assert.Contains(t, result.stderr, "unexpected number of arguments")
+	})
+
+	t.Run("pings the server and verifies the token.", func(t *testing.T) {
+		connection := startServer(t)
+
+		result := runCommand("", withConnection("ping", connection)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, `"status": "ok"`)
+
+		result = runCommand("", withConnection("verify-token", connection, "--output", "table")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, "STATUS")
+		assert.Contains(t, result.stdout, "ok")
+	})
+
+	t.Run("connects using a connection string.", func(t *testing.T) {
+		connection := startServer(t)
+		baseURL, err := url.Parse(connection[1])
+		require.NoError(t, err)
+
+		connectionString := fmt.Sprintf("esdb://%s@%s", connection[3], baseURL.Host)
+		result := runCommand("", "verify-token", "--connection-string", connectionString)
+
+		assert.Equal(t, 0, result.exitCode, result.stderr)
+	})
+
+	t.Run("uses the API token flag with the URL from the environment.", func(t *testing.T) {
+		connection := startServer(t)
+		t.Setenv("EVENTSOURCINGDB_URL", connection[1])
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN", "")
+
+		result := runCommand("", "verify-token", "--api-token", connection[3])
+
+		assert.Equal(t, 0, result.exitCode, result.stderr)
+	})
+
+	t.Run("rejects the API token flag together with a connection string.", func(t *testing.T) {
+		result := runCommand("", "verify-token", "--connection-string", "esdb://secret@localhost:3000", "--api-token", "secret")
+
+		assert.Equal(t, 1, result.exitCode)
+		assert.Contains(t, result.stderr, "must not be given with --connection-string")
+
+		t.Setenv("EVENTSOURCINGDB_CONNECTION_STRING", "esdb://secret@localhost:3000")
+
+		result = runCommand("", "verify-token", "--api-token", "secret")
+
+		assert.Equal(t, 1, result.exitCode)
+		assert.Contains(t, result.stderr, "must not be given with $EVENTSOURCINGDB_CONNECTION_STRING")
+	})
+
+	t.Run("fails for an invalid API token.", func(t *testing.T) {
+		connection := startServer(t)
+		connection[3] = "invalid"
+
+		result := runCommand("", withConnection("verify-token", connection)...)
+
+		assert.Equal(t, 1, result.exitCode)
+		assert.Contains(t, result.stderr, "esdb verify-token:")
+	})
+
+	t.Run("writes and reads events.", func(t *testing.T) {
+		connection := startServer(t)
+
+		events := `{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-acquired","data":{"title":"2001"}}
+{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-borrowed","data":{"by":"Jane"}}
+`
+		result := runCommand(events, withConnection("write", connection, "--is-subject-pristine", "/books/42")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		var writtenEvents []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.stdout), &writtenEvents))
+		require.Len(t, writtenEvents, 2)
+		assert.Equal(t, "0", writtenEvents[0]["id"])
+
+		result = runCommand(events, withConnection("write", connection, "--is-subject-pristine", "/books/42")...)
+		assert.Equal(t, 1, result.exitCode)
+
+		result = runCommand("", withConnection("read", connection, "--output", "ndjson", "--order", "antichronological", "/books/42")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"type":"io.eventsourcingdb.library.book-borrowed"`)
+		assert.Contains(t, lines[1], `"type":"io.eventsourcingdb.library.book-acquired"`)
+
+		result = runCommand("", withConnection("read", connection, "--output", "table", "--recursive", "--lower-bound", "1")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, "io.eventsourcingdb.library.book-borrowed")
+		assert.NotContains(t, result.stdout, "io.eventsourcingdb.library.book-acquired")
+
+		result = runCommand("", withConnection("subjects", connection, "--output", "ndjson")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Equal(t, "\"/\"\n\"/books\"\n\"/books/42\"\n", result.stdout)
+	})
+
+	t.Run("registers schemas and lists event types.", func(t *testing.T) {
+		connection := startServer(t)
+
+		schema := `{"type":"object","properties":{"title":{"type":"string"}},"required":["title"],"additionalProperties":false}`
+		result := runCommand(schema, withConnection("register-schema", connection, "io.eventsourcingdb.library.book-acquired")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		result = runCommand("", withConnection("types", connection, "io.eventsourcingdb.library.book-acquired")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		var eventType eventTypeResult
+		require.NoError(t, json.Unmarshal([]byte(result.stdout), &eventType))
+		assert.Equal(t, "io.eventsourcingdb.library.book-acquired", eventType.EventType)
+		assert.True(t, eventType.IsPhantom)
+		assert.NotNil(t, eventType.Schema)
+
+		result = runCommand("", withConnection("types", connection, "--output", "table")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, "EVENT TYPE")
+		assert.Contains(t, result.stdout, "io.eventsourcingdb.library.book-acquired")
+	})
+
+	t.Run("backs up and restores events.", func(t *testing.T) {
+		source := startServer(t)
+		target := startServer(t)
+
+		events := `[{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-acquired","data":{"title":"2001"}}]`
+		result := runCommand(events, withConnection("write", source)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		archive := filepath.Join(t.TempDir(), "backup.ndjson.gz")
+		result = runCommand("", withConnection("backup", source, archive)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, `"eventCount": 1`)
+
+		result = runCommand("", withConnection("backup", source, archive)...)
+		assert.Equal(t, 1, result.exitCode)
+
+		result = runCommand("", withConnection("restore", target, "--output", "table", archive)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, "EVENTS")
+
+		result = runCommand("", withConnection("read", target, "--recursive", "--output", "ndjson")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		assert.Contains(t, result.stdout, `"subject":"/books/42"`)
+
+		result = runCommand("", withConnection("restore", target, archive)...)
+		assert.Equal(t, 1, result.exitCode)
+		assert.Contains(t, result.stderr, "restore target is not empty")
+	})
+
+	t.Run("audits an export.", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer().WithSigningKey()
+		connection := startConfiguredServer(t, server)
+
+		verificationKey, err := server.GetVerificationKey()
+		require.NoError(t, err)
+		verificationKeyBytes, err := x509.MarshalPKIXPublicKey(*verificationKey)
+		require.NoError(t, err)
+		verificationKeyFile := filepath.Join(t.TempDir(), "verification-key.pem")
+		err = os.WriteFile(verificationKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: verificationKeyBytes}), 0600)
+		require.NoError(t, err)
+
+		events := `{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-acquired","data":{"title":"2001"}}
+{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-borrowed","data":{"by":"Jane"}}
+{"source":"https://www.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-returned","data":{"by":"Jane"}}
+`
+		result := runCommand(events, withConnection("write", connection)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		result = runCommand("", withConnection("read", connection, "--recursive", "--output", "ndjson")...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+		exportFile := filepath.Join(t.TempDir(), "export.ndjson")
+		export := result.stdout
+		require.NoError(t, os.WriteFile(exportFile, []byte(export), 0600))
+
+		backupFile := filepath.Join(t.TempDir(), "backup.ndjson.gz")
+		result = runCommand("", withConnection("backup", connection, backupFile)...)
+		require.Equal(t, 0, result.exitCode, result.stderr)
+
+		auditFile := func(t *testing.T, file string, args ...string) (commandResult, auditReport) {
+			t.Helper()
+
+			result := runCommand("", append(append([]string{"audit"}, args...), file)...)
+
+			var report auditReport
+			require.NoError(t, json.Unmarshal([]byte(result.stdout), &report), result.stderr)
+
+			return result, report
+		}
+
+		t.Run("accepts a valid export.", func(t *testing.T) {
+			result, report := auditFile(t, exportFile, "--verification-key", verificationKeyFile)
+
+			assert.Equal(t, 0, result.exitCode, result.stderr)
+			assert.True(t, report.IsValid)
+			assert.True(t, report.AreSignaturesVerified)
+			assert.Equal(t, 3, report.EventCount)
+			assert.Equal(t, "0", report.FirstEventID)
+			assert.Equal(t, "2", report.LastEventID)
+			assert.Empty(t, report.Violations)
+		})
+
+		t.Run("accepts a valid backup.", func(t *testing.T) {
+			result, report := auditFile(t, backupFile, "--verification-key", verificationKeyFile)
+
+			assert.Equal(t, 0, result.exitCode, result.stderr)
+			assert.True(t, report.IsValid)
+			assert.Equal(t, 3, report.EventCount)
+		})
+
+		t.Run("reports events that have been tampered with.", func(t *testing.T) {
+			tamperedFile := filepath.Join(t.TempDir(), "export.ndjson")
+			tamperedExport := strings.Replace(export, `"by":"Jane"`, `"by":"John"`, 1)
+			require.NoError(t, os.WriteFile(tamperedFile, []byte(tamperedExport), 0600))
+
+			result, report := auditFile(t, tamperedFile, "--skip-signatures")
+
+			assert.Equal(t, 1, result.exitCode)
+			assert.Contains(t, result.stderr, "found 1 violation(s)")
+			assert.False(t, report.IsValid)
+			assert.Equal(t, []auditViolation{
+				{Line: 2, EventID: "1", Check: auditCheckHash, Message: "hash verification failed"},
+			}, report.Violations)
+		})
+
+		t.Run("reports missing events.", func(t *testing.T) {
+			incompleteFile := filepath.Join(t.TempDir(), "export.ndjson")
+			lines := strings.SplitAfter(export, "\n")
+			require.NoError(t, os.WriteFile(incompleteFile, []byte(lines[0]+lines[2]), 0600))
+
+			result, report := auditFile(t, incompleteFile, "--skip-signatures")
+
+			assert.Equal(t, 1, result.exitCode)
+			require.Len(t, report.Violations, 1)
+			assert.Equal(t, "2", report.Violations[0].EventID)
+			assert.Equal(t, auditCheckPredecessorHash, report.Violations[0].Check)
+		})
+
+		t.Run("reports a missing first event.", func(t *testing.T) {
+			incompleteFile := filepath.Join(t.TempDir(), "export.ndjson")
+			lines := strings.SplitAfter(export, "\n")
+			require.NoError(t, os.WriteFile(incompleteFile, []byte(lines[1]+lines[2]), 0600))
+
+			result, report := auditFile(t, incompleteFile, "--verification-key", verificationKeyFile)
+
+			assert.Equal(t, 1, result.exitCode)
+			require.Len(t, report.Violations, 1)
+			assert.Equal(t, "1", report.Violations[0].EventID)
+			assert.Equal(t, auditCheckPredecessorHash, report.Violations[0].Check)
+		})
+
+		t.Run("skips signatures only if asked to.", func(t *testing.T) {
+			result := runCommand("", "audit", exportFile)
+
+			assert.Equal(t, 1, result.exitCode)
+			assert.Contains(t, result.stderr, "either --verification-key or --skip-signatures must be given")
+
+			result, report := auditFile(t, exportFile, "--skip-signatures")
+
+			assert.Equal(t, 0, result.exitCode, result.stderr)
+			assert.True(t, report.IsValid)
+			assert.False(t, report.AreSignaturesVerified)
+		})
+
+		t.Run("reports invalid signatures.", func(t *testing.T) {
+			otherVerificationKey, _, err := ed25519.GenerateKey(nil)
+			require.NoError(t, err)
+			otherVerificationKeyBytes, err := x509.MarshalPKIXPublicKey(otherVerificationKey)
+			require.NoError(t, err)
+			otherVerificationKeyFile := filepath.Join(t.TempDir(), "verification-key.pem")
+			err = os.WriteFile(otherVerificationKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: otherVerificationKeyBytes}), 0600)
+			require.NoError(t, err)
+
+			result, report := auditFile(t, exportFile, "--verification-key", otherVerificationKeyFile)
+
+			assert.Equal(t, 1, result.exitCode)
+			require.Len(t, report.Violations, 3)
+			for _, violation := range report.Violations {
+				assert.Equal(t, auditCheckSignature, violation.Check)
+			}
+		})
+
+		t.Run("reports lines that are not events.", func(t *testing.T) {
+			invalidFile := filepath.Join(t.TempDir(), "export.ndjson")
+			require.NoError(t, os.WriteFile(invalidFile, []byte(export+"not json\n"), 0600))
+
+			result, report := auditFile(t, invalidFile, "--skip-signatures")
+
+			assert.Equal(t, 1, result.exitCode)
+			require.Len(t, report.Violations, 1)
+			assert.Equal(t, 4, report.Violations[0].Line)
+			assert.Equal(t, auditCheckFormat, report.Violations[0].Check)
+		})
+	})
+
+	t.Run("fails for invalid events on stdin.", func(t *testing.T) {
+		connection := startServer(t)
+
+		result := runCommand(`{"unknown":true}`, withConnection("write", connection)...)
+
+		assert.Equal(t, 1, result.exitCode)
+		assert.Contains(t, result.stderr, "invalid events")
+	})
+}
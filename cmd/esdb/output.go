@@ -0,0 +1,114 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatTable  = "table"
+)
+
+type outputMode int
+
+const (
+	// outputModeSingle prints a single value.
+	outputModeSingle outputMode = iota
+	// outputModeList prints a finite list of values, as an array in JSON.
+	outputModeList
+	// outputModeStream prints values as they arrive, since the list never
+	// ends, e.g. when observing events.
+	outputModeStream
+)
+
+func registerOutputFlag(flagSet *flag.FlagSet) *string {
+	return flagSet.String("output", formatJSON, "output format: json, ndjson, or table")
+}
+
+// output prints values in the format selected by the user. Every value is
+// given both as a value to serialize to JSON and as a row for tables.
+type output struct {
+	format    string
+	mode      outputMode
+	writer    io.Writer
+	headers   []string
+	items     []any
+	table     *tabwriter.Writer
+	hasHeader bool
+}
+
+func newOutput(format string, mode outputMode, writer io.Writer, headers ...string) (*output, error) {
+	switch format {
+	case formatJSON, formatNDJSON, formatTable:
+	default:
+		return nil, fmt.Errorf("unsupported output format '%s'", format)
+	}
+
+	return &output{
+		format:  format,
+		mode:    mode,
+		writer:  writer,
+		headers: headers,
+		items:   []any{},
+		table:   tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0),
+	}, nil
+}
+
+func (o *output) write(item any, row ...string) error {
+	switch o.format {
+	case formatNDJSON:
+		return writeJSON(o.writer, item, false)
+	case formatTable:
+		o.writeTableHeader()
+		fmt.Fprintln(o.table, strings.Join(row, "\t"))
+		if o.mode == outputModeStream {
+			return o.table.Flush()
+		}
+		return nil
+	default:
+		if o.mode == outputModeList {
+			o.items = append(o.items, item)
+			return nil
+		}
+		return writeJSON(o.writer, item, true)
+	}
+}
+
+func (o *output) close() error {
+	switch o.format {
+	case formatTable:
+		o.writeTableHeader()
+		return o.table.Flush()
+	case formatJSON:
+		if o.mode == outputModeList {
+			return writeJSON(o.writer, o.items, true)
+		}
+	}
+
+	return nil
+}
+
+func (o *output) writeTableHeader() {
+	if o.hasHeader {
+		return
+	}
+
+	fmt.Fprintln(o.table, strings.Join(o.headers, "\t"))
+	o.hasHeader = true
+}
+
+func writeJSON(w io.Writer, value any, isIndented bool) error {
+	encoder := internal.NewJSONEncoder(w)
+	if isIndented {
+		encoder.SetIndent("", "  ")
+	}
+
+	return encoder.Encode(value)
+}
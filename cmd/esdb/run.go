@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+type environment struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(ctx context.Context, env *environment, args []string) error
+}
+
+// errUsage signals that a command was called incorrectly. The command has
+// already printed the details, so only the exit code differs.
+var errUsage = errors.New("invalid usage")
+
+func getCommands() []command {
+	return []command{
+		{"ping", "", "Check whether the instance is reachable", runPing},
+		{"verify-token", "", "Verify the API token", runVerifyToken},
+		{"write", "", "Write events from stdin (JSON or NDJSON)", runWrite},
+		{"read", "[subject]", "Read events", runRead},
+		{"observe", "[subject]", "Observe events until interrupted", runObserve},
+		{"query", "<query>", "Run an EventQL query", runQuery},
+		{"subjects", "[base-subject]", "List subjects", runSubjects},
+		{"types", "[event-type]", "List event types, or show a single one", runTypes},
+		{"register-schema", "<event-type>", "Register an event schema from stdin or a file", runRegisterSchema},
+		{"backup", "<file>", "Back up all events and event schemas to a compressed archive", runBackup},
+		{"restore", "<file>", "Restore a backup into an empty instance", runRestore},
+		{"audit", "<file>", "Verify the hashes and signatures of an export without connecting to an instance", runAudit},
+	}
+}
+
+func run(ctx context.Context, args []string, env *environment) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(env.stderr)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	for _, command := range getCommands() {
+		if command.name != args[0] {
+			continue
+		}
+
+		err := command.run(ctx, env, args[1:])
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		default:
+			fmt.Fprintf(env.stderr, "esdb %s: %v\n", command.name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(env.stderr, "esdb: unknown command '%s'\n\n", args[0])
+	printUsage(env.stderr)
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: esdb <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, command := range getCommands() {
+		fmt.Fprintf(w, "  %-16s %s\n", command.name, command.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'esdb <command> -h' for the flags of a command.")
+}
+
+func newFlagSet(env *environment, command string) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(command, flag.ContinueOnError)
+	flagSet.SetOutput(env.stderr)
+	flagSet.Usage = func() {
+		for _, c := range getCommands() {
+			if c.name == command {
+				fmt.Fprintf(env.stderr, "Usage: esdb %s [flags] %s\n\n%s.\n\nFlags:\n", c.name, c.usage, c.description)
+			}
+		}
+		flagSet.PrintDefaults()
+	}
+
+	return flagSet
+}
+
+// parseFlags parses the flags of a command, and checks that the number of
+// remaining arguments is within the given range.
+func parseFlags(flagSet *flag.FlagSet, args []string, minArgs int, maxArgs int) error {
+	err := flagSet.Parse(args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	if flagSet.NArg() < minArgs || flagSet.NArg() > maxArgs {
+		fmt.Fprintf(flagSet.Output(), "unexpected number of arguments: %d\n\n", flagSet.NArg())
+		flagSet.Usage()
+		return errUsage
+	}
+
+	return nil
+}
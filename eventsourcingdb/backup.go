@@ -0,0 +1,91 @@
+package eventsourcingdb
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// BackupManifest describes the contents of a backup. It is written as the last
+// line of the archive, so an archive without a manifest is incomplete.
+type BackupManifest struct {
+	EventCount   int                 `json:"eventCount"`
+	FirstEventID string              `json:"firstEventId,omitempty"`
+	LastEventID  string              `json:"lastEventId,omitempty"`
+	FinalHash    string              `json:"finalHash,omitempty"`
+	EventSchemas []BackupEventSchema `json:"eventSchemas"`
+}
+
+type BackupEventSchema struct {
+	EventType string         `json:"eventType"`
+	Schema    map[string]any `json:"schema"`
+}
+
+// Backup reads all events of the given event store in chronological order, and
+// writes them to the given writer as a gzip-compressed NDJSON archive, followed
+// by a manifest that contains the registered event schemas.
+func Backup(ctx context.Context, eventStore EventStore, writer io.Writer) (BackupManifest, error) {
+	gzipWriter := gzip.NewWriter(writer)
+	encoder := internal.NewJSONEncoder(gzipWriter)
+
+	manifest := BackupManifest{
+		EventSchemas: []BackupEventSchema{},
+	}
+
+	for event, err := range eventStore.ReadEvents(ctx, "/", ReadEventsOptions{Recursive: true}) {
+		if err != nil {
+			return BackupManifest{}, err
+		}
+
+		err = encoder.Encode(backupLine{
+			Type:    "event",
+			Payload: NewCloudEventFromEvent(event),
+		})
+		if err != nil {
+			return BackupManifest{}, err
+		}
+
+		if manifest.EventCount == 0 {
+			manifest.FirstEventID = event.ID
+		}
+		manifest.EventCount++
+		manifest.LastEventID = event.ID
+		manifest.FinalHash = event.Hash
+	}
+
+	for eventType, err := range eventStore.ReadEventTypes(ctx) {
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		if eventType.Schema == nil {
+			continue
+		}
+
+		manifest.EventSchemas = append(manifest.EventSchemas, BackupEventSchema{
+			EventType: eventType.EventType,
+			Schema:    *eventType.Schema,
+		})
+	}
+
+	err := encoder.Encode(backupLine{
+		Type:    "manifest",
+		Payload: manifest,
+	})
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	return manifest, nil
+}
+
+type backupLine struct {
+	Type    string `json:"type"`
+	Payload any    `json:"payload"`
+}
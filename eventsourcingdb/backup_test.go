@@ -0,0 +1,234 @@
+package eventsourcingdb_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	type EventData struct {
+		Value int `json:"value"`
+	}
+
+	newEventCandidate := func(subject string, value int) eventsourcingdb.EventCandidate {
+		return eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: subject,
+			Type:    "io.eventsourcingdb.test",
+			Data: EventData{
+				Value: value,
+			},
+		}
+	}
+
+	openStore := func(t *testing.T) *eventsourcingdb.EmbeddedStore {
+		t.Helper()
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(filepath.Join(t.TempDir(), "events.ndjson"))
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+
+		return store
+	}
+
+	readEvents := func(t *testing.T, eventStore eventsourcingdb.Reader) []eventsourcingdb.Event {
+		t.Helper()
+
+		events := []eventsourcingdb.Event{}
+		for event, err := range eventStore.ReadEvents(
+			context.Background(),
+			"/",
+			eventsourcingdb.ReadEventsOptions{Recursive: true},
+		) {
+			require.NoError(t, err)
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	createBackup := func(t *testing.T) (*eventsourcingdb.EmbeddedStore, []byte) {
+		t.Helper()
+
+		source := openStore(t)
+		_, err := source.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEventCandidate("/test/1", 23),
+			newEventCandidate("/test/2", 42),
+			newEventCandidate("/test/1", 7),
+		}, nil)
+		require.NoError(t, err)
+
+		err = source.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{
+			"type": "object",
+		})
+		require.NoError(t, err)
+
+		var archive bytes.Buffer
+		_, err = eventsourcingdb.Backup(context.Background(), source, &archive)
+		require.NoError(t, err)
+
+		return source, archive.Bytes()
+	}
+
+	rewriteArchive := func(t *testing.T, archive []byte, rewrite func(content string) string) []byte {
+		t.Helper()
+
+		gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+		require.NoError(t, err)
+		content, err := io.ReadAll(gzipReader)
+		require.NoError(t, err)
+
+		var rewrittenArchive bytes.Buffer
+		gzipWriter := gzip.NewWriter(&rewrittenArchive)
+		_, err = gzipWriter.Write([]byte(rewrite(string(content))))
+		require.NoError(t, err)
+		require.NoError(t, gzipWriter.Close())
+
+		return rewrittenArchive.Bytes()
+	}
+
+	t.Run("creates a manifest that describes the backup", func(t *testing.T) {
+		source := openStore(t)
+		writtenEvents, err := source.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEventCandidate("/test/1", 23),
+			newEventCandidate("/test/2", 42),
+		}, nil)
+		require.NoError(t, err)
+
+		err = source.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{
+			"type": "object",
+		})
+		require.NoError(t, err)
+
+		var archive bytes.Buffer
+		manifest, err := eventsourcingdb.Backup(context.Background(), source, &archive)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, manifest.EventCount)
+		assert.Equal(t, "0", manifest.FirstEventID)
+		assert.Equal(t, "1", manifest.LastEventID)
+		assert.Equal(t, writtenEvents[1].Hash, manifest.FinalHash)
+		require.Len(t, manifest.EventSchemas, 1)
+		assert.Equal(t, "io.eventsourcingdb.test", manifest.EventSchemas[0].EventType)
+		assert.Equal(t, map[string]any{"type": "object"}, manifest.EventSchemas[0].Schema)
+	})
+
+	t.Run("backs up an empty event store", func(t *testing.T) {
+		var archive bytes.Buffer
+		manifest, err := eventsourcingdb.Backup(context.Background(), openStore(t), &archive)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, manifest.EventCount)
+		assert.Empty(t, manifest.EventSchemas)
+
+		target := openStore(t)
+		_, err = eventsourcingdb.Restore(context.Background(), target, &archive, eventsourcingdb.RestoreOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, readEvents(t, target))
+	})
+
+	t.Run("restores events and event schemas", func(t *testing.T) {
+		source, archive := createBackup(t)
+		target := openStore(t)
+
+		manifest, err := eventsourcingdb.Restore(
+			context.Background(),
+			target,
+			bytes.NewReader(archive),
+			eventsourcingdb.RestoreOptions{BatchSize: 2},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 3, manifest.EventCount)
+
+		sourceEvents := readEvents(t, source)
+		targetEvents := readEvents(t, target)
+		require.Len(t, targetEvents, len(sourceEvents))
+		for i, targetEvent := range targetEvents {
+			assert.Equal(t, sourceEvents[i].ID, targetEvent.ID)
+			assert.Equal(t, sourceEvents[i].Subject, targetEvent.Subject)
+			assert.Equal(t, sourceEvents[i].Type, targetEvent.Type)
+			assert.JSONEq(t, string(sourceEvents[i].Data), string(targetEvent.Data))
+		}
+
+		eventType, err := target.ReadEventType("io.eventsourcingdb.test")
+		require.NoError(t, err)
+		require.NotNil(t, eventType.Schema)
+		assert.Equal(t, map[string]any{"type": "object"}, *eventType.Schema)
+	})
+
+	t.Run("rejects a target that is not empty", func(t *testing.T) {
+		_, archive := createBackup(t)
+		target := openStore(t)
+		_, err := target.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEventCandidate("/test/1", 1),
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = eventsourcingdb.Restore(context.Background(), target, bytes.NewReader(archive), eventsourcingdb.RestoreOptions{})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrRestoreTargetNotEmpty)
+		assert.Len(t, readEvents(t, target), 1)
+	})
+
+	t.Run("rejects an event that has been tampered with", func(t *testing.T) {
+		_, archive := createBackup(t)
+		archive = rewriteArchive(t, archive, func(content string) string {
+			return strings.Replace(content, `{"value":42}`, `{"value":43}`, 1)
+		})
+
+		_, err := eventsourcingdb.Restore(context.Background(), openStore(t), bytes.NewReader(archive), eventsourcingdb.RestoreOptions{})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrInvalidBackup)
+		assert.ErrorContains(t, err, "event '1'")
+	})
+
+	t.Run("rejects a broken hash chain", func(t *testing.T) {
+		_, archive := createBackup(t)
+		archive = rewriteArchive(t, archive, func(content string) string {
+			lines := strings.SplitAfter(content, "\n")
+			return lines[0] + lines[2] + lines[3]
+		})
+
+		_, err := eventsourcingdb.Restore(context.Background(), openStore(t), bytes.NewReader(archive), eventsourcingdb.RestoreOptions{})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrInvalidBackup)
+		assert.ErrorContains(t, err, "predecessor hash of event '2'")
+	})
+
+	t.Run("rejects a backup that does not start with the first event", func(t *testing.T) {
+		_, archive := createBackup(t)
+		archive = rewriteArchive(t, archive, func(content string) string {
+			lines := strings.SplitAfter(content, "\n")
+			return strings.Join(lines[1:], "")
+		})
+		target := openStore(t)
+
+		_, err := eventsourcingdb.Restore(context.Background(), target, bytes.NewReader(archive), eventsourcingdb.RestoreOptions{BatchSize: 1})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrInvalidBackup)
+		assert.ErrorContains(t, err, "predecessor hash of event '1' is not the initial hash")
+		assert.Empty(t, readEvents(t, target))
+	})
+
+	t.Run("rejects a backup without a manifest", func(t *testing.T) {
+		_, archive := createBackup(t)
+		archive = rewriteArchive(t, archive, func(content string) string {
+			lines := strings.SplitAfter(content, "\n")
+			return strings.Join(lines[:3], "")
+		})
+
+		_, err := eventsourcingdb.Restore(context.Background(), openStore(t), bytes.NewReader(archive), eventsourcingdb.RestoreOptions{})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrInvalidBackup)
+		assert.ErrorContains(t, err, "manifest is missing")
+	})
+
+	t.Run("rejects data that is not a backup", func(t *testing.T) {
+		_, err := eventsourcingdb.Restore(context.Background(), openStore(t), strings.NewReader("not a backup"), eventsourcingdb.RestoreOptions{})
+		assert.ErrorIs(t, err, eventsourcingdb.ErrInvalidBackup)
+	})
+}
@@ -0,0 +1,21 @@
+package eventsourcingdb
+
+import "context"
+
+type callbackTokenProvider struct {
+	callback func(ctx context.Context) (string, error)
+}
+
+func NewCallbackTokenProvider(callback func(ctx context.Context) (string, error)) TokenProvider {
+	return callbackTokenProvider{
+		callback,
+	}
+}
+
+func (p callbackTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return p.callback(ctx)
+}
+
+func (p callbackTokenProvider) RefreshToken(ctx context.Context) (string, error) {
+	return p.callback(ctx)
+}
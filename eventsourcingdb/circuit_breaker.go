@@ -0,0 +1,261 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState string
+
+const (
+	CircuitStateClosed   CircuitState = "closed"
+	CircuitStateOpen     CircuitState = "open"
+	CircuitStateHalfOpen CircuitState = "half-open"
+)
+
+type CircuitBreakerOptions struct {
+	// ConsecutiveFailures trips the circuit breaker after the given number of
+	// failed requests in a row. Zero disables this condition.
+	ConsecutiveFailures int
+	// ErrorRate trips the circuit breaker if the share of failed requests
+	// within Window reaches the given value between 0 and 1, provided that
+	// there were at least MinimumRequests requests. Zero disables this
+	// condition.
+	ErrorRate       float64
+	MinimumRequests int
+	Window          time.Duration
+	// OpenDuration is how long the circuit breaker stays open before it
+	// probes the server using a ping.
+	OpenDuration  time.Duration
+	OnStateChange func(from CircuitState, to CircuitState)
+}
+
+func validateCircuitBreakerOptions(options CircuitBreakerOptions) error {
+	if options.ConsecutiveFailures < 0 {
+		return errors.New("consecutive failures must not be negative")
+	}
+	if options.ErrorRate < 0 || options.ErrorRate > 1 {
+		return errors.New("error rate must be between 0 and 1")
+	}
+	if options.ConsecutiveFailures == 0 && options.ErrorRate == 0 {
+		return errors.New("either consecutive failures or error rate must be set")
+	}
+	if options.ErrorRate > 0 && options.MinimumRequests < 1 {
+		return errors.New("minimum requests must be at least one")
+	}
+	if options.ErrorRate > 0 && options.Window <= 0 {
+		return errors.New("window must be greater than zero")
+	}
+	if options.OpenDuration <= 0 {
+		return errors.New("open duration must be greater than zero")
+	}
+
+	return nil
+}
+
+type circuitBreaker struct {
+	mutex               sync.Mutex
+	options             CircuitBreakerOptions
+	state               CircuitState
+	consecutiveFailures int
+	windowStart         time.Time
+	windowRequests      int
+	windowFailures      int
+	openedAt            time.Time
+}
+
+func newCircuitBreaker(options CircuitBreakerOptions) *circuitBreaker {
+	return &circuitBreaker{
+		options: options,
+		state:   CircuitStateClosed,
+	}
+}
+
+func (b *circuitBreaker) getState() CircuitState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.state
+}
+
+// setState must be called with the mutex held. It returns a function that
+// notifies the state change callback, which must be called after releasing
+// the mutex, so that the callback may safely use the client.
+func (b *circuitBreaker) setState(state CircuitState) func() {
+	from := b.state
+	b.state = state
+
+	b.consecutiveFailures = 0
+	b.windowStart = time.Time{}
+	b.windowRequests = 0
+	b.windowFailures = 0
+	if state == CircuitStateOpen {
+		b.openedAt = time.Now()
+	}
+
+	if b.options.OnStateChange == nil || from == state {
+		return func() {}
+	}
+
+	return func() {
+		b.options.OnStateChange(from, state)
+	}
+}
+
+// allow returns whether a request may be sent. If the circuit breaker has been
+// open for long enough, it moves to the half-open state, and the caller must
+// probe the server and report the outcome using finishProbe.
+func (b *circuitBreaker) allow() (bool, error) {
+	b.mutex.Lock()
+
+	switch b.state {
+	case CircuitStateClosed:
+		b.mutex.Unlock()
+		return false, nil
+	case CircuitStateOpen:
+		if time.Since(b.openedAt) < b.options.OpenDuration {
+			b.mutex.Unlock()
+			return false, ErrCircuitOpen
+		}
+
+		notify := b.setState(CircuitStateHalfOpen)
+		b.mutex.Unlock()
+		notify()
+
+		return true, nil
+	default:
+		// Another caller is probing the server already.
+		b.mutex.Unlock()
+		return false, ErrCircuitOpen
+	}
+}
+
+func (b *circuitBreaker) finishProbe(err error) {
+	b.mutex.Lock()
+
+	state := CircuitStateClosed
+	if err != nil {
+		state = CircuitStateOpen
+	}
+
+	notify := b.setState(state)
+	b.mutex.Unlock()
+	notify()
+}
+
+func (b *circuitBreaker) recordResult(isFailure bool) {
+	b.mutex.Lock()
+
+	if b.state != CircuitStateClosed {
+		b.mutex.Unlock()
+		return
+	}
+
+	if isFailure {
+		b.consecutiveFailures++
+	} else {
+		b.consecutiveFailures = 0
+	}
+
+	now := time.Now()
+	if now.Sub(b.windowStart) >= b.options.Window {
+		b.windowStart = now
+		b.windowRequests = 0
+		b.windowFailures = 0
+	}
+	b.windowRequests++
+	if isFailure {
+		b.windowFailures++
+	}
+
+	hasTooManyConsecutiveFailures := b.options.ConsecutiveFailures > 0 &&
+		b.consecutiveFailures >= b.options.ConsecutiveFailures
+	hasTooHighErrorRate := b.options.ErrorRate > 0 &&
+		b.windowRequests >= b.options.MinimumRequests &&
+		float64(b.windowFailures)/float64(b.windowRequests) >= b.options.ErrorRate
+
+	if !hasTooManyConsecutiveFailures && !hasTooHighErrorRate {
+		b.mutex.Unlock()
+		return
+	}
+
+	notify := b.setState(CircuitStateOpen)
+	b.mutex.Unlock()
+	notify()
+}
+
+func (c *Client) CircuitState() CircuitState {
+	if c.circuitBreaker == nil {
+		return CircuitStateClosed
+	}
+
+	return c.circuitBreaker.getState()
+}
+
+func (c *Client) checkCircuitBreaker(ctx context.Context) error {
+	if c.circuitBreaker == nil {
+		return nil
+	}
+
+	isProbe, err := c.circuitBreaker.allow()
+	if err != nil {
+		return err
+	}
+	if !isProbe {
+		return nil
+	}
+
+	for _, endpoint := range c.endpoints {
+		err = c.ping(ctx, endpoint)
+		if err == nil {
+			break
+		}
+	}
+
+	c.circuitBreaker.finishProbe(err)
+	if err != nil {
+		return fmt.Errorf("%w, probing the server failed: %w", ErrCircuitOpen, err)
+	}
+
+	return nil
+}
+
+func (c *Client) recordCircuitBreakerResult(response *http.Response, err error) {
+	if c.circuitBreaker == nil {
+		return
+	}
+
+	// Canceling a request is the caller's decision, so it says nothing about
+	// the health of the server.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	// Errors that occur before a request is sent, such as an incompatible
+	// server version, a rejected server header, or a failing token provider,
+	// are caused by the configuration of the client, not by the server, so
+	// they must not open the circuit for everyone sharing the client.
+	if err != nil {
+		if isTransportError(err) {
+			c.circuitBreaker.recordResult(true)
+		}
+		return
+	}
+
+	c.circuitBreaker.recordResult(response.StatusCode >= http.StatusInternalServerError)
+}
+
+func isTransportError(err error) bool {
+	var urlError *url.Error
+	var netError net.Error
+
+	return errors.As(err, &urlError) || errors.As(err, &netError)
+}
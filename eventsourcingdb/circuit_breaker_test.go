@@ -0,0 +1,258 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+type circuitBreakerTestServer struct {
+	baseURL  *url.URL
+	requests atomic.Int32
+	pings    atomic.Int32
+	isDown   atomic.Bool
+}
+
+func newCircuitBreakerTestServer(t *testing.T) *circuitBreakerTestServer {
+	t.Helper()
+
+	testServer := &circuitBreakerTestServer{}
+	testServer.baseURL = newStubServer(t, stubServerOptions{
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/ping" {
+					testServer.pings.Add(1)
+				} else {
+					testServer.requests.Add(1)
+				}
+
+				if testServer.isDown.Load() {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+			})
+		},
+	})
+
+	return testServer
+}
+
+type stateChangeRecorder struct {
+	mutex        sync.Mutex
+	stateChanges []string
+}
+
+func (r *stateChangeRecorder) record(from eventsourcingdb.CircuitState, to eventsourcingdb.CircuitState) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stateChanges = append(r.stateChanges, fmt.Sprintf("%s->%s", from, to))
+}
+
+func (r *stateChangeRecorder) get() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.stateChanges...)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("opens after consecutive failures and fails fast", func(t *testing.T) {
+		server := newCircuitBreakerTestServer(t)
+		server.isDown.Store(true)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+				ConsecutiveFailures: 3,
+				OpenDuration:        time.Minute,
+			}),
+		)
+		require.NoError(t, err)
+
+		for range 3 {
+			err = client.VerifyAPIToken()
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, eventsourcingdb.ErrCircuitOpen)
+		}
+		assert.Equal(t, eventsourcingdb.CircuitStateOpen, client.CircuitState())
+
+		err = client.VerifyAPIToken()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrCircuitOpen)
+		assert.Equal(t, int32(3), server.requests.Load())
+	})
+
+	t.Run("opens if the error rate is too high", func(t *testing.T) {
+		server := newCircuitBreakerTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+				ErrorRate:       0.5,
+				MinimumRequests: 4,
+				Window:          time.Minute,
+				OpenDuration:    time.Minute,
+			}),
+		)
+		require.NoError(t, err)
+
+		for _, isDown := range []bool{false, true, false} {
+			server.isDown.Store(isDown)
+			_ = client.VerifyAPIToken()
+		}
+		assert.Equal(t, eventsourcingdb.CircuitStateClosed, client.CircuitState())
+
+		server.isDown.Store(true)
+		_ = client.VerifyAPIToken()
+		assert.Equal(t, eventsourcingdb.CircuitStateOpen, client.CircuitState())
+	})
+
+	t.Run("closes again once a probe succeeds", func(t *testing.T) {
+		server := newCircuitBreakerTestServer(t)
+		server.isDown.Store(true)
+
+		recorder := &stateChangeRecorder{}
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+				ConsecutiveFailures: 1,
+				OpenDuration:        50 * time.Millisecond,
+				OnStateChange:       recorder.record,
+			}),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.Error(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		// The probe fails, since the server is still down, so the circuit
+		// breaker opens again without sending the actual request.
+		err = client.VerifyAPIToken()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrCircuitOpen)
+		assert.Equal(t, int32(1), server.pings.Load())
+		assert.Equal(t, int32(1), server.requests.Load())
+
+		server.isDown.Store(false)
+		time.Sleep(100 * time.Millisecond)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+		assert.Equal(t, eventsourcingdb.CircuitStateClosed, client.CircuitState())
+
+		assert.Equal(t, []string{
+			"closed->open",
+			"open->half-open",
+			"half-open->open",
+			"open->half-open",
+			"half-open->closed",
+		}, recorder.get())
+	})
+
+	t.Run("does not count client errors as failures", func(t *testing.T) {
+		server := newCircuitBreakerTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+				ConsecutiveFailures: 1,
+				OpenDuration:        time.Minute,
+			}),
+		)
+		require.NoError(t, err)
+
+		_, err = client.ReadEventType("io.eventsourcingdb.library.book-acquired")
+		require.Error(t, err)
+		assert.Equal(t, eventsourcingdb.CircuitStateClosed, client.CircuitState())
+	})
+
+	t.Run("does not count errors caused by the client configuration as failures", func(t *testing.T) {
+		circuitBreaker := eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+			ConsecutiveFailures: 1,
+			OpenDuration:        time.Minute,
+		})
+
+		incompatibleServerURL := newStubServer(t, stubServerOptions{Version: "0.9.0"})
+		server := newCircuitBreakerTestServer(t)
+
+		clients := map[string][]eventsourcingdb.ClientOption{
+			"incompatible server version": {
+				eventsourcingdb.WithServerVersionCheck(eventsourcingdb.ServerVersionCheckStrict),
+			},
+			"rejected server header": {
+				eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewCustomServerHeaderPolicy("X-Missing")),
+			},
+			"failing token provider": {
+				eventsourcingdb.WithTokenProvider(eventsourcingdb.NewCallbackTokenProvider(
+					func(ctx context.Context) (string, error) {
+						return "", assert.AnError
+					},
+				)),
+			},
+		}
+
+		for name, options := range clients {
+			baseURL := server.baseURL
+			if name == "incompatible server version" {
+				baseURL = incompatibleServerURL
+			}
+
+			client, err := eventsourcingdb.NewClient(baseURL, "secret", append(options, circuitBreaker)...)
+			require.NoError(t, err, name)
+
+			for range 2 {
+				err = client.VerifyAPIToken()
+				require.Error(t, err, name)
+				assert.NotErrorIs(t, err, eventsourcingdb.ErrCircuitOpen, name)
+			}
+			assert.Equal(t, eventsourcingdb.CircuitStateClosed, client.CircuitState(), name)
+		}
+	})
+
+	t.Run("counts unreachable servers as failures", func(t *testing.T) {
+		client, err := eventsourcingdb.NewClient(
+			newUnreachableURL(t),
+			"secret",
+			eventsourcingdb.WithCircuitBreaker(eventsourcingdb.CircuitBreakerOptions{
+				ConsecutiveFailures: 1,
+				OpenDuration:        time.Minute,
+			}),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.Error(t, err)
+		assert.Equal(t, eventsourcingdb.CircuitStateOpen, client.CircuitState())
+	})
+
+	t.Run("returns an error for invalid options", func(t *testing.T) {
+		baseURL, err := url.Parse("http://localhost:3000")
+		require.NoError(t, err)
+
+		for _, options := range []eventsourcingdb.CircuitBreakerOptions{
+			{OpenDuration: time.Minute},
+			{ConsecutiveFailures: 1},
+			{ErrorRate: 1.5, MinimumRequests: 1, Window: time.Minute, OpenDuration: time.Minute},
+			{ErrorRate: 0.5, Window: time.Minute, OpenDuration: time.Minute},
+		} {
+			_, err = eventsourcingdb.NewClient(baseURL, "secret", eventsourcingdb.WithCircuitBreaker(options))
+			assert.Error(t, err)
+		}
+	})
+}
@@ -1,18 +1,131 @@
 package eventsourcingdb
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Client struct {
-	baseURL  *url.URL
-	apiToken string
+	baseURL                     *url.URL
+	failoverURLs                []*url.URL
+	endpoints                   []*endpoint
+	endpointSelection           EndpointSelection
+	healthCheckInterval         time.Duration
+	roundRobinCounter           atomic.Uint64
+	observeEndpoint             atomic.Pointer[endpoint]
+	serverVersionCheck          ServerVersionCheck
+	serverVersionWarningHandler func(err error)
+	serverHeaderPolicy          ServerHeaderPolicy
+	isServerVerified            atomic.Bool
+	tokenProvider               TokenProvider
+	tlsConfig                   *tls.Config
+	timeout                     time.Duration
+	unixSocketPath              string
+	requestCompression          bool
+	responseCompression         bool
+	limits                      *limits
+	operationLimits             map[Operation]*limits
+	circuitBreaker              *circuitBreaker
+	closeMutex                  sync.Mutex
+	isClosed                    bool
+	activeRequests              sync.WaitGroup
+	closeCtx                    context.Context
+	cancelActiveRequests        context.CancelFunc
+	transportWrappers           []func(transport http.RoundTripper) http.RoundTripper
+	httpClient                  *http.Client
 }
 
-func NewClient(baseURL *url.URL, apiToken string) (*Client, error) {
+func NewClient(baseURL *url.URL, apiToken string, options ...ClientOption) (*Client, error) {
+	err := validateBaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
 	client := &Client{
-		baseURL,
-		apiToken,
+		baseURL:                     baseURL,
+		endpointSelection:           EndpointSelectionPrimarySecondary,
+		healthCheckInterval:         5 * time.Second,
+		serverVersionCheck:          ServerVersionCheckWarn,
+		serverVersionWarningHandler: defaultServerVersionWarningHandler,
+		serverHeaderPolicy:          NewStrictServerHeaderPolicy(),
+		tokenProvider:               NewStaticTokenProvider(apiToken),
+		limits:                      &limits{},
+		operationLimits:             map[Operation]*limits{},
+	}
+	client.closeCtx, client.cancelActiveRequests = context.WithCancel(context.Background())
+
+	// For a Unix socket, requests are sent over HTTP to a placeholder host,
+	// while the transport dials the socket instead. This way, the API paths
+	// are still resolved by getURL as for any other base URL.
+	if baseURL.Scheme == "unix" {
+		client.baseURL = &url.URL{Scheme: "http", Host: "localhost"}
+		client.unixSocketPath = baseURL.Path
+	}
+
+	for _, option := range options {
+		err := option(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if client.unixSocketPath != "" && len(client.failoverURLs) > 0 {
+		return nil, errors.New("failover URLs are not supported when connecting via a Unix socket")
+	}
+
+	client.endpoints = []*endpoint{newEndpoint(client.baseURL)}
+	for _, failoverURL := range client.failoverURLs {
+		client.endpoints = append(client.endpoints, newEndpoint(failoverURL))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if client.tlsConfig != nil {
+		transport.TLSClientConfig = client.tlsConfig
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	// The timeout only covers establishing the connection and waiting for the
+	// response headers. It deliberately does not limit reading the body, since
+	// streams such as ObserveEvents are expected to stay open indefinitely.
+	if client.timeout > 0 {
+		dialer.Timeout = client.timeout
+		transport.TLSHandshakeTimeout = client.timeout
+		transport.ResponseHeaderTimeout = client.timeout
+	}
+
+	// Compression is negotiated explicitly by the client, see WithRequestCompression
+	// and WithResponseCompression, so the transport must not request and decode
+	// gzip-encoded responses on its own.
+	transport.DisableCompression = true
+
+	transport.DialContext = dialer.DialContext
+	if client.unixSocketPath != "" {
+		transport.DialContext = func(ctx context.Context, network string, address string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", client.unixSocketPath)
+		}
+	}
+
+	var roundTripper http.RoundTripper = transport
+	for _, wrapTransport := range client.transportWrappers {
+		roundTripper = wrapTransport(roundTripper)
+		if roundTripper == nil {
+			return nil, errors.New("transport wrapper must not return nil")
+		}
+	}
+
+	client.httpClient = &http.Client{
+		Transport: roundTripper,
 	}
 
 	return client, nil
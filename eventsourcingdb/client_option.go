@@ -0,0 +1,217 @@
+package eventsourcingdb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+type ClientOption func(c *Client) error
+
+func WithTokenProvider(tokenProvider TokenProvider) ClientOption {
+	return func(c *Client) error {
+		if tokenProvider == nil {
+			return errors.New("token provider must not be nil")
+		}
+
+		c.tokenProvider = tokenProvider
+		return nil
+	}
+}
+
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return errors.New("timeout must be greater than zero")
+		}
+
+		c.timeout = timeout
+		return nil
+	}
+}
+
+func WithUnixSocket(path string) ClientOption {
+	return func(c *Client) error {
+		if path == "" {
+			return errors.New("socket path must not be empty")
+		}
+
+		c.unixSocketPath = path
+		return nil
+	}
+}
+
+func WithFailoverURLs(failoverURLs ...*url.URL) ClientOption {
+	return func(c *Client) error {
+		for _, failoverURL := range failoverURLs {
+			err := validateBaseURL(failoverURL)
+			if err != nil {
+				return fmt.Errorf("invalid failover URL: %w", err)
+			}
+			if failoverURL.Scheme == "unix" {
+				return errors.New("invalid failover URL: Unix sockets are not supported as failover URLs")
+			}
+		}
+
+		c.failoverURLs = append(c.failoverURLs, failoverURLs...)
+		return nil
+	}
+}
+
+func WithEndpointSelection(endpointSelection EndpointSelection) ClientOption {
+	return func(c *Client) error {
+		switch endpointSelection {
+		case EndpointSelectionPrimarySecondary, EndpointSelectionRoundRobin:
+			c.endpointSelection = endpointSelection
+			return nil
+		default:
+			return fmt.Errorf("unsupported endpoint selection '%s'", endpointSelection)
+		}
+	}
+}
+
+func WithHealthCheckInterval(interval time.Duration) ClientOption {
+	return func(c *Client) error {
+		if interval <= 0 {
+			return errors.New("health check interval must be greater than zero")
+		}
+
+		c.healthCheckInterval = interval
+		return nil
+	}
+}
+
+func WithServerVersionCheck(serverVersionCheck ServerVersionCheck) ClientOption {
+	return func(c *Client) error {
+		switch serverVersionCheck {
+		case ServerVersionCheckDisabled, ServerVersionCheckWarn, ServerVersionCheckStrict:
+			c.serverVersionCheck = serverVersionCheck
+			return nil
+		default:
+			return fmt.Errorf("unsupported server version check '%s'", serverVersionCheck)
+		}
+	}
+}
+
+func WithServerVersionWarningHandler(handler func(err error)) ClientOption {
+	return func(c *Client) error {
+		if handler == nil {
+			return errors.New("server version warning handler must not be nil")
+		}
+
+		c.serverVersionWarningHandler = handler
+		return nil
+	}
+}
+
+func WithServerHeaderPolicy(serverHeaderPolicy ServerHeaderPolicy) ClientOption {
+	return func(c *Client) error {
+		if serverHeaderPolicy == nil {
+			return errors.New("server header policy must not be nil")
+		}
+		if policy, ok := serverHeaderPolicy.(customServerHeaderPolicy); ok && policy.HeaderName() == "" {
+			return errors.New("header name must not be empty")
+		}
+
+		c.serverHeaderPolicy = serverHeaderPolicy
+		return nil
+	}
+}
+
+func WithRequestCompression() ClientOption {
+	return func(c *Client) error {
+		c.requestCompression = true
+		return nil
+	}
+}
+
+func WithResponseCompression() ClientOption {
+	return func(c *Client) error {
+		c.responseCompression = true
+		return nil
+	}
+}
+
+func WithRateLimit(rateLimit RateLimit) ClientOption {
+	return func(c *Client) error {
+		err := validateRateLimit(rateLimit)
+		if err != nil {
+			return err
+		}
+
+		c.limits.tokenBucket = internal.NewTokenBucket(rateLimit.RequestsPerSecond, rateLimit.Burst)
+		return nil
+	}
+}
+
+func WithMaxConcurrentRequests(maxConcurrentRequests int) ClientOption {
+	return func(c *Client) error {
+		if maxConcurrentRequests < 1 {
+			return errors.New("max concurrent requests must be at least one")
+		}
+
+		c.limits.semaphore = internal.NewSemaphore(maxConcurrentRequests)
+		return nil
+	}
+}
+
+func WithOperationRateLimit(operation Operation, rateLimit RateLimit) ClientOption {
+	return func(c *Client) error {
+		if !isSupportedOperation(operation) {
+			return fmt.Errorf("unsupported operation '%s'", operation)
+		}
+
+		err := validateRateLimit(rateLimit)
+		if err != nil {
+			return err
+		}
+
+		c.getOperationLimits(operation).tokenBucket = internal.NewTokenBucket(rateLimit.RequestsPerSecond, rateLimit.Burst)
+		return nil
+	}
+}
+
+func WithOperationMaxConcurrentRequests(operation Operation, maxConcurrentRequests int) ClientOption {
+	return func(c *Client) error {
+		if !isSupportedOperation(operation) {
+			return fmt.Errorf("unsupported operation '%s'", operation)
+		}
+		if maxConcurrentRequests < 1 {
+			return errors.New("max concurrent requests must be at least one")
+		}
+
+		c.getOperationLimits(operation).semaphore = internal.NewSemaphore(maxConcurrentRequests)
+		return nil
+	}
+}
+
+func WithCircuitBreaker(options CircuitBreakerOptions) ClientOption {
+	return func(c *Client) error {
+		err := validateCircuitBreakerOptions(options)
+		if err != nil {
+			return err
+		}
+
+		c.circuitBreaker = newCircuitBreaker(options)
+		return nil
+	}
+}
+
+// WithTransport wraps the transport the client sends its requests with, e.g. to
+// record, replay, or manipulate them. The given function receives the transport
+// configured by the other options, and returns the transport to use instead.
+// If the option is given multiple times, the wrappers are applied in order.
+func WithTransport(wrapTransport func(transport http.RoundTripper) http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if wrapTransport == nil {
+			return errors.New("transport wrapper must not be nil")
+		}
+
+		c.transportWrappers = append(c.transportWrappers, wrapTransport)
+		return nil
+	}
+}
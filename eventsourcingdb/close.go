@@ -0,0 +1,69 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrClientClosed = errors.New("client is closed")
+
+// Close shuts down the client. It cancels all active requests, including the
+// streams of iterators such as ReadEvents and ObserveEvents, which then end
+// the same way as if their context had been canceled. Close waits for them to
+// finish, or until ctx is done, and finally closes idle connections. Once Close
+// has been called, all further calls fail with ErrClientClosed. Calling Close
+// more than once is safe.
+func (c *Client) Close(ctx context.Context) error {
+	c.closeMutex.Lock()
+	c.isClosed = true
+	c.closeMutex.Unlock()
+
+	c.cancelActiveRequests()
+
+	drained := make(chan struct{})
+	go func() {
+		c.activeRequests.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for active requests to finish: %w", ctx.Err())
+	}
+
+	c.httpClient.CloseIdleConnections()
+
+	return nil
+}
+
+func (c *Client) getIsClosed() bool {
+	c.closeMutex.Lock()
+	defer c.closeMutex.Unlock()
+
+	return c.isClosed
+}
+
+// trackRequest registers a request as active, so that Close can wait for it.
+// The returned context is canceled once the client is closed, and the returned
+// function must be called once the request is done, i.e. once its response
+// body has been closed.
+func (c *Client) trackRequest(ctx context.Context) (context.Context, func(), error) {
+	c.closeMutex.Lock()
+	defer c.closeMutex.Unlock()
+
+	if c.isClosed {
+		return nil, nil, ErrClientClosed
+	}
+	c.activeRequests.Add(1)
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.closeCtx, cancel)
+
+	return ctx, func() {
+		stop()
+		cancel()
+		c.activeRequests.Done()
+	}, nil
+}
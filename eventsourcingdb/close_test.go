@@ -0,0 +1,134 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestClose(t *testing.T) {
+	observeEvents := func(client *eventsourcingdb.Client, onEvent func()) (<-chan struct{}, <-chan error) {
+		done := make(chan struct{})
+		errs := make(chan error, 1)
+
+		go func() {
+			defer close(done)
+
+			for _, err := range client.ObserveEvents(context.Background(), "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+				if err != nil {
+					errs <- err
+				}
+				onEvent()
+			}
+		}()
+
+		return done, errs
+	}
+
+	waitForRequests := func(t *testing.T, server *failoverTestServer, count int32) {
+		t.Helper()
+
+		require.Eventually(t, func() bool {
+			return server.requests.Load() >= count
+		}, time.Second, 10*time.Millisecond)
+	}
+
+	t.Run("cancels active streams and waits for them to finish", func(t *testing.T) {
+		server := newFailoverTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(server.baseURL, "secret")
+		require.NoError(t, err)
+
+		firstDone, firstErrs := observeEvents(client, func() {})
+		secondDone, secondErrs := observeEvents(client, func() {})
+		waitForRequests(t, server, 2)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err = client.Close(ctx)
+		require.NoError(t, err)
+
+		for _, done := range []<-chan struct{}{firstDone, secondDone} {
+			select {
+			case <-done:
+			default:
+				t.Fatal("stream is still active after closing the client")
+			}
+		}
+		assert.Empty(t, firstErrs)
+		assert.Empty(t, secondErrs)
+	})
+
+	t.Run("rejects new calls once closed", func(t *testing.T) {
+		server := newFailoverTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(server.baseURL, "secret")
+		require.NoError(t, err)
+
+		err = client.Close(context.Background())
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrClientClosed)
+
+		err = client.VerifyAPIToken()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrClientClosed)
+
+		for _, err := range client.ReadSubjects(context.Background(), "/") {
+			assert.ErrorIs(t, err, eventsourcingdb.ErrClientClosed)
+		}
+
+		assert.Equal(t, int32(0), server.requests.Load())
+	})
+
+	t.Run("returns an error if streams do not finish in time", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Server", "EventSourcingDB/test")
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			fmt.Fprint(w, `{"type":"event","payload":{"specversion":"1.0","id":"0","subject":"/books/42","type":"io.eventsourcingdb.library.book-acquired","data":{}}}`+"\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		t.Cleanup(server.Close)
+
+		baseURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		// The consumer blocks while handling the first line, so the stream
+		// can not finish until it is unblocked.
+		unblock := make(chan struct{})
+		done, _ := observeEvents(client, func() {
+			<-unblock
+		})
+		require.Eventually(t, func() bool {
+			return requests.Load() == 1
+		}, time.Second, 10*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err = client.Close(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		close(unblock)
+		<-done
+
+		err = client.Close(context.Background())
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,9 @@
+package eventsourcingdb
+
+import (
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// CloudEvent is an event in the CloudEvents format, as EventSourcingDB uses it
+// in its API, and as it is stored in exports and backups.
+type CloudEvent = internal.CloudEvent
@@ -0,0 +1,97 @@
+package eventsourcingdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func compressRequestBody(body []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(body)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decompressResponse replaces the body of a gzip-encoded response with a
+// reader that decompresses it on the fly, so that NDJSON streams can be
+// consumed line by line without buffering the entire response.
+func decompressResponse(response *http.Response) {
+	if !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+
+	response.Body = &gzipResponseBody{
+		body: response.Body,
+	}
+	response.Header.Del("Content-Encoding")
+	response.Header.Del("Content-Length")
+	response.ContentLength = -1
+	response.Uncompressed = true
+}
+
+// gzipResponseBody creates the gzip reader lazily on the first read, since
+// creating it reads the gzip header, which would block until the server sends
+// the first bytes of a stream such as ObserveEvents.
+type gzipResponseBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (b *gzipResponseBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	if b.reader == nil {
+		reader, err := gzip.NewReader(b.body)
+		if err != nil {
+			b.err = err
+			return 0, err
+		}
+		b.reader = reader
+	}
+
+	return b.reader.Read(p)
+}
+
+// Close closes both the gzip reader, if it has been created, and the
+// underlying body, and returns the first error.
+func (b *gzipResponseBody) Close() error {
+	var readerErr error
+	if b.reader != nil {
+		readerErr = b.reader.Close()
+	}
+
+	bodyErr := b.body.Close()
+	if readerErr != nil {
+		return readerErr
+	}
+
+	return bodyErr
+}
+
+func isNDJSONPath(path string) bool {
+	switch path {
+	case "/api/v1/read-events",
+		"/api/v1/observe-events",
+		"/api/v1/run-eventql-query",
+		"/api/v1/read-subjects",
+		"/api/v1/read-event-types":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,254 @@
+package eventsourcingdb_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+type compressionTestServer struct {
+	baseURL               *url.URL
+	eventCount            int
+	requestBytes          atomic.Int64
+	responseBytes         atomic.Int64
+	requestContentType    atomic.Value
+	requestEncoding       atomic.Value
+	requestAcceptEncoding atomic.Value
+	decodedRequestBody    atomic.Value
+}
+
+// countingWriter counts the bytes that are actually sent over the wire, i.e.
+// after compression.
+type countingWriter struct {
+	writer io.Writer
+	count  *atomic.Int64
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count.Add(int64(n))
+	return n, err
+}
+
+// responseWriterWithBody writes the body of a response to another writer,
+// e.g. to compress it, while headers and status codes are sent as usual.
+type responseWriterWithBody struct {
+	http.ResponseWriter
+	writer io.Writer
+}
+
+func (w responseWriterWithBody) Write(p []byte) (int, error) {
+	return w.writer.Write(p)
+}
+
+func newCompressionTestServer(tb testing.TB, eventCount int) *compressionTestServer {
+	tb.Helper()
+
+	testServer := &compressionTestServer{
+		eventCount: eventCount,
+	}
+
+	testServer.baseURL = newStubServer(tb, stubServerOptions{
+		Routes: map[string]http.HandlerFunc{
+			"/api/v1/write-events": func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `[]`)
+			},
+			"/api/v1/read-events": func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				for i := range testServer.eventCount {
+					fmt.Fprintf(
+						w,
+						`{"type":"event","payload":{"specversion":"1.0","id":"%d","time":"2025-01-01T00:00:00Z","source":"https://library.eventsourcingdb.io","subject":"/books/42","type":"io.eventsourcingdb.library.book-acquired","datacontenttype":"application/json","data":{"title":"2001 – A Space Odyssey","author":"Arthur C. Clarke","isbn":"978-0756906788"},"hash":"%064d","predecessorhash":"%064d"}}`+"\n",
+						i, i+1, i,
+					)
+				}
+			},
+		},
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rawBody, err := io.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				testServer.requestBytes.Add(int64(len(rawBody)))
+				testServer.requestContentType.Store(r.Header.Get("Content-Type"))
+				testServer.requestEncoding.Store(r.Header.Get("Content-Encoding"))
+				testServer.requestAcceptEncoding.Store(r.Header.Get("Accept-Encoding"))
+
+				body := rawBody
+				if r.Header.Get("Content-Encoding") == "gzip" {
+					reader, err := gzip.NewReader(bytes.NewReader(rawBody))
+					if err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					body, err = io.ReadAll(reader)
+					if err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+				}
+				testServer.decodedRequestBody.Store(string(body))
+
+				var writer io.Writer = countingWriter{writer: w, count: &testServer.responseBytes}
+				if r.Header.Get("Accept-Encoding") == "gzip" {
+					w.Header().Set("Content-Encoding", "gzip")
+					gzipWriter := gzip.NewWriter(writer)
+					defer gzipWriter.Close()
+					writer = gzipWriter
+				}
+
+				next.ServeHTTP(responseWriterWithBody{ResponseWriter: w, writer: writer}, r)
+			})
+		},
+	})
+
+	return testServer
+}
+
+func getBookAcquiredEvents(count int) []eventsourcingdb.EventCandidate {
+	events := make([]eventsourcingdb.EventCandidate, 0, count)
+	for range count {
+		events = append(events, eventsourcingdb.EventCandidate{
+			Source:  "https://library.eventsourcingdb.io",
+			Subject: "/books/42",
+			Type:    "io.eventsourcingdb.library.book-acquired",
+			Data: map[string]any{
+				"title":  "2001 – A Space Odyssey",
+				"author": "Arthur C. Clarke",
+				"isbn":   "978-0756906788",
+			},
+		})
+	}
+
+	return events
+}
+
+func readAllEvents(tb testing.TB, client *eventsourcingdb.Client) int {
+	tb.Helper()
+
+	count := 0
+	for _, err := range client.ReadEvents(context.Background(), "/books/42", eventsourcingdb.ReadEventsOptions{Recursive: false}) {
+		require.NoError(tb, err)
+		count++
+	}
+
+	return count
+}
+
+func TestCompression(t *testing.T) {
+	t.Run("does not compress by default", func(t *testing.T) {
+		server := newCompressionTestServer(t, 10)
+
+		client, err := eventsourcingdb.NewClient(server.baseURL, "secret")
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents(getBookAcquiredEvents(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", server.requestEncoding.Load())
+
+		assert.Equal(t, 10, readAllEvents(t, client))
+		assert.Equal(t, "", server.requestAcceptEncoding.Load())
+	})
+
+	t.Run("compresses request bodies", func(t *testing.T) {
+		server := newCompressionTestServer(t, 0)
+
+		client, err := eventsourcingdb.NewClient(server.baseURL, "secret", eventsourcingdb.WithRequestCompression())
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents(getBookAcquiredEvents(100), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "gzip", server.requestEncoding.Load())
+		assert.Equal(t, "application/json", server.requestContentType.Load())
+		assert.Contains(t, server.decodedRequestBody.Load(), `"subject":"/books/42"`)
+		assert.Less(t, server.requestBytes.Load(), int64(len(server.decodedRequestBody.Load().(string))))
+	})
+
+	t.Run("decompresses NDJSON responses", func(t *testing.T) {
+		uncompressedServer := newCompressionTestServer(t, 100)
+		compressedServer := newCompressionTestServer(t, 100)
+
+		uncompressedClient, err := eventsourcingdb.NewClient(uncompressedServer.baseURL, "secret")
+		require.NoError(t, err)
+		compressedClient, err := eventsourcingdb.NewClient(compressedServer.baseURL, "secret", eventsourcingdb.WithResponseCompression())
+		require.NoError(t, err)
+
+		assert.Equal(t, 100, readAllEvents(t, uncompressedClient))
+		assert.Equal(t, 100, readAllEvents(t, compressedClient))
+
+		assert.Equal(t, "gzip", compressedServer.requestAcceptEncoding.Load())
+		assert.Less(t, compressedServer.responseBytes.Load(), uncompressedServer.responseBytes.Load())
+	})
+
+	t.Run("does not negotiate compression for JSON responses", func(t *testing.T) {
+		server := newCompressionTestServer(t, 0)
+
+		client, err := eventsourcingdb.NewClient(server.baseURL, "secret", eventsourcingdb.WithResponseCompression())
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents(getBookAcquiredEvents(1), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", server.requestAcceptEncoding.Load())
+	})
+}
+
+func BenchmarkWriteEvents(b *testing.B) {
+	for _, compressed := range []bool{false, true} {
+		b.Run(fmt.Sprintf("compressed=%t", compressed), func(b *testing.B) {
+			server := newCompressionTestServer(b, 0)
+
+			var options []eventsourcingdb.ClientOption
+			if compressed {
+				options = append(options, eventsourcingdb.WithRequestCompression())
+			}
+
+			client, err := eventsourcingdb.NewClient(server.baseURL, "secret", options...)
+			require.NoError(b, err)
+
+			events := getBookAcquiredEvents(1_000)
+
+			for b.Loop() {
+				_, err := client.WriteEvents(events, nil)
+				require.NoError(b, err)
+			}
+
+			b.ReportMetric(float64(server.requestBytes.Load())/float64(b.N), "wire-bytes/op")
+		})
+	}
+}
+
+func BenchmarkReadEvents(b *testing.B) {
+	for _, compressed := range []bool{false, true} {
+		b.Run(fmt.Sprintf("compressed=%t", compressed), func(b *testing.B) {
+			server := newCompressionTestServer(b, 1_000)
+
+			var options []eventsourcingdb.ClientOption
+			if compressed {
+				options = append(options, eventsourcingdb.WithResponseCompression())
+			}
+
+			client, err := eventsourcingdb.NewClient(server.baseURL, "secret", options...)
+			require.NoError(b, err)
+
+			for b.Loop() {
+				readAllEvents(b, client)
+			}
+
+			b.ReportMetric(float64(server.responseBytes.Load())/float64(b.N), "wire-bytes/op")
+		})
+	}
+}
@@ -5,38 +5,92 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	dockercontainer "github.com/moby/moby/api/types/container"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+const (
+	containerDataDirectory = "/var/lib/esdb"
+	imageVariableName      = "EVENTSOURCINGDB_IMAGE"
 )
 
 type Container struct {
-	imageName    string
-	imageTag     string
-	internalPort int
-	apiToken     string
-	signingKey   *ed25519.PrivateKey
-	container    testcontainers.Container
+	imageName            string
+	imageTag             string
+	dockerfilePath       string
+	image                string
+	serverInfo           *ServerInfo
+	internalPort         int
+	apiToken             string
+	signingKey           *ed25519.PrivateKey
+	certificateAuthority *internal.CertificateAuthority
+	certificatePEM       []byte
+	privateKeyPEM        []byte
+	seeds                []containerSeed
+	seedSchemas          map[string]map[string]any
+	dataDirectory        string
+	dataSnapshotPath     string
+	extraArgs            []string
+	env                  map[string]string
+	startupTimeout       time.Duration
+	logConsumers         []testcontainers.LogConsumer
+	container            testcontainers.Container
+}
+
+// containerSeed contains either events or the path of a file to read events
+// from, so that seeds are written in the order they were configured.
+type containerSeed struct {
+	events   []EventCandidate
+	filePath string
 }
 
 func NewContainer() *Container {
 	return &Container{
-		imageName:    "thenativeweb/eventsourcingdb",
-		imageTag:     "latest",
-		internalPort: 3000,
-		apiToken:     "secret",
-		signingKey:   nil,
+		imageName:      "thenativeweb/eventsourcingdb",
+		imageTag:       "latest",
+		internalPort:   3000,
+		apiToken:       "secret",
+		signingKey:     nil,
+		env:            map[string]string{},
+		startupTimeout: 10 * time.Second,
 	}
 }
 
 func (c *Container) WithImageTag(tag string) *Container {
 	c.imageTag = tag
+	c.dockerfilePath = ""
+	return c
+}
+
+// WithImage sets the image to use in the form name:tag. If the tag is
+// omitted, latest is used.
+func (c *Container) WithImage(image string) *Container {
+	c.imageName, c.imageTag = splitImage(image)
+	c.dockerfilePath = ""
+	return c
+}
+
+// WithImageFromDockerfile uses the image of the first FROM instruction in the
+// given Dockerfile, so that the image version is pinned in a single place. The
+// Dockerfile is read when the container is started.
+func (c *Container) WithImageFromDockerfile(path string) *Container {
+	c.dockerfilePath = path
 	return c
 }
 
@@ -54,20 +108,164 @@ func (c *Container) WithSigningKey() *Container {
 	return c
 }
 
+func (c *Container) WithHTTPS() *Container {
+	certificateAuthority, err := internal.NewCertificateAuthority()
+	if err != nil {
+		panic(err)
+	}
+
+	certificatePEM, privateKeyPEM, err := certificateAuthority.IssueCertificate(
+		"localhost",
+		[]string{"localhost", "127.0.0.1", "::1"},
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	c.certificateAuthority = certificateAuthority
+	c.certificatePEM = certificatePEM
+	c.privateKeyPEM = privateKeyPEM
+	return c
+}
+
 func (c *Container) WithPort(port int) *Container {
 	c.internalPort = port
 	return c
 }
 
+func (c *Container) WithDataDirectory(hostPath string) *Container {
+	c.dataDirectory = hostPath
+	return c
+}
+
+func (c *Container) WithExtraArgs(args ...string) *Container {
+	c.extraArgs = append(c.extraArgs, args...)
+	return c
+}
+
+func (c *Container) WithEnv(key string, value string) *Container {
+	c.env[key] = value
+	return c
+}
+
+func (c *Container) WithStartupTimeout(timeout time.Duration) *Container {
+	c.startupTimeout = timeout
+	return c
+}
+
+// WithLogHandler hands every log line of the container to the given handler,
+// e.g. to show them in tests, see eventsourcingdbtest.NewTestLogHandler.
+func (c *Container) WithLogHandler(handler func(line string)) *Container {
+	c.logConsumers = append(c.logConsumers, logHandlerConsumer{handler: handler})
+	return c
+}
+
+func (c *Container) WithSeedEvents(events ...EventCandidate) *Container {
+	c.seeds = append(c.seeds, containerSeed{events: events})
+	return c
+}
+
+func (c *Container) WithSeedFile(path string) *Container {
+	c.seeds = append(c.seeds, containerSeed{filePath: path})
+	return c
+}
+
+func (c *Container) WithSeedSchemas(schemas map[string]map[string]any) *Container {
+	if c.seedSchemas == nil {
+		c.seedSchemas = map[string]map[string]any{}
+	}
+	for eventType, schema := range schemas {
+		c.seedSchemas[eventType] = schema
+	}
+	return c
+}
+
 func (c *Container) Start(ctx context.Context) error {
+	// Seed files are read before starting the container, so that invalid
+	// files are reported without waiting for Docker.
+	seedEvents, err := c.readSeedEvents()
+	if err != nil {
+		return err
+	}
+
+	image, err := c.getImage()
+	if err != nil {
+		return err
+	}
+
 	files := []testcontainers.ContainerFile{}
 
 	cmd := []string{
 		"run",
 		"--api-token", c.apiToken,
-		"--data-directory-temporary",
-		"--http-enabled",
-		"--https-enabled=false",
+	}
+
+	var binds []string
+	var tmpfs map[string]string
+	var dataDirectory string
+
+	if c.dataDirectory != "" {
+		dataDirectory, err = filepath.Abs(c.dataDirectory)
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(dataDirectory, 0755)
+		if err != nil {
+			return err
+		}
+
+		binds = append(binds, dataDirectory+":"+containerDataDirectory)
+	} else {
+		// Docker empties a tmpfs mount whenever the container stops, so
+		// restarting the container starts with an empty data directory.
+		tmpfs = map[string]string{containerDataDirectory: ""}
+	}
+	cmd = append(cmd, "--data-directory", containerDataDirectory)
+
+	hostConfigModifier := func(hostConfig *dockercontainer.HostConfig) {
+		hostConfig.Binds = append(hostConfig.Binds, binds...)
+	}
+
+	waitStrategy := wait.
+		ForHTTP("/api/v1/ping").
+		WithPort(fmt.Sprintf("%d/tcp", c.internalPort)).
+		WithStartupTimeout(c.startupTimeout)
+
+	if c.certificateAuthority != nil {
+		certificatePath := "/etc/esdb/https-certificate.pem"
+		privateKeyPath := "/etc/esdb/https-private-key.pem"
+
+		files = append(files,
+			testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(c.certificatePEM),
+				ContainerFilePath: certificatePath,
+				FileMode:          0644,
+			},
+			testcontainers.ContainerFile{
+				Reader:            bytes.NewReader(c.privateKeyPEM),
+				ContainerFilePath: privateKeyPath,
+				FileMode:          0600,
+			},
+		)
+		cmd = append(cmd,
+			"--http-enabled=false",
+			"--https-enabled",
+			"--https-certificate-file", certificatePath,
+			"--https-private-key-file", privateKeyPath,
+		)
+
+		rootCAs := x509.NewCertPool()
+		rootCAs.AppendCertsFromPEM(c.certificateAuthority.CertificatePEM)
+		waitStrategy = waitStrategy.WithTLS(true, &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		})
+	} else {
+		cmd = append(cmd,
+			"--http-enabled",
+			"--https-enabled=false",
+		)
 	}
 
 	if c.signingKey != nil {
@@ -85,20 +283,35 @@ func (c *Container) Start(ctx context.Context) error {
 		files = append(files, testcontainers.ContainerFile{
 			Reader:            reader,
 			ContainerFilePath: targetPath,
-			FileMode:          0777,
+			FileMode:          0600,
 		})
 		cmd = append(cmd, "--signing-key-file", targetPath)
 	}
 
+	cmd = append(cmd, c.extraArgs...)
+
 	request := testcontainers.ContainerRequest{
-		Image:        fmt.Sprintf("%s:%s", c.imageName, c.imageTag),
-		ExposedPorts: []string{fmt.Sprintf("%d/tcp", c.internalPort)},
-		Files:        files,
-		Cmd:          cmd,
-		WaitingFor: wait.
-			ForHTTP("/api/v1/ping").
-			WithPort(fmt.Sprintf("%d/tcp", c.internalPort)).
-			WithStartupTimeout(10 * time.Second),
+		Image:              image,
+		ExposedPorts:       []string{fmt.Sprintf("%d/tcp", c.internalPort)},
+		Files:              files,
+		Cmd:                cmd,
+		Env:                c.env,
+		Tmpfs:              tmpfs,
+		HostConfigModifier: hostConfigModifier,
+		WaitingFor:         waitStrategy,
+	}
+	if len(c.logConsumers) > 0 {
+		request.LogConsumerCfg = &testcontainers.LogConsumerConfig{
+			Consumers: c.logConsumers,
+		}
+	}
+
+	// The data directory is restored to this snapshot by Reset.
+	if dataDirectory != "" {
+		c.dataSnapshotPath, err = internal.SnapshotDirectory(dataDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot data directory: %w", err)
+		}
 	}
 
 	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
@@ -106,10 +319,136 @@ func (c *Container) Start(ctx context.Context) error {
 		Started:          true,
 	})
 	if err != nil {
+		c.removeDataSnapshot()
 		return err
 	}
 
 	c.container = container
+	c.image = image
+
+	return c.initialize(ctx, seedEvents)
+}
+
+// initialize is called whenever the server has been started, both by Start and
+// by Reset.
+func (c *Container) initialize(ctx context.Context, seedEvents []EventCandidate) error {
+	err := c.readServerInfo(ctx)
+	if err != nil {
+		_ = c.Stop(ctx)
+		return fmt.Errorf("failed to read server info: %w", err)
+	}
+
+	err = c.seed(ctx, seedEvents)
+	if err != nil {
+		_ = c.Stop(ctx)
+		return fmt.Errorf("failed to seed container: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Container) removeDataSnapshot() {
+	if c.dataSnapshotPath == "" {
+		return
+	}
+
+	_ = os.RemoveAll(c.dataSnapshotPath)
+	c.dataSnapshotPath = ""
+}
+
+// getImage returns the image to start, which can be overridden using the
+// EVENTSOURCINGDB_IMAGE environment variable, e.g. to pin the image in CI.
+func (c *Container) getImage() (string, error) {
+	image := os.Getenv(imageVariableName)
+	if image != "" {
+		return image, nil
+	}
+
+	if c.dockerfilePath != "" {
+		return internal.GetImageFromDockerfile(c.dockerfilePath)
+	}
+
+	return fmt.Sprintf("%s:%s", c.imageName, c.imageTag), nil
+}
+
+func splitImage(image string) (string, string) {
+	separatorIndex := strings.LastIndex(image, ":")
+	if separatorIndex == -1 || strings.Contains(image[separatorIndex:], "/") {
+		return image, "latest"
+	}
+
+	return image[:separatorIndex], image[separatorIndex+1:]
+}
+
+func (c *Container) readServerInfo(ctx context.Context) error {
+	client, err := c.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	serverInfo, err := client.ServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.serverInfo = &serverInfo
+	return nil
+}
+
+func (c *Container) readSeedEvents() ([]EventCandidate, error) {
+	var seedEvents []EventCandidate
+	for _, seed := range c.seeds {
+		if seed.filePath == "" {
+			seedEvents = append(seedEvents, seed.events...)
+			continue
+		}
+
+		events, err := readSeedFile(seed.filePath)
+		if err != nil {
+			return nil, err
+		}
+		seedEvents = append(seedEvents, events...)
+	}
+
+	return seedEvents, nil
+}
+
+func (c *Container) seed(ctx context.Context, seedEvents []EventCandidate) error {
+	if len(c.seedSchemas) == 0 && len(seedEvents) == 0 {
+		return nil
+	}
+
+	client, err := c.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// With a persistent data directory, the container may have been seeded
+	// before, so seeds are only applied to an empty database.
+	for _, err := range client.ReadEventTypes(ctx) {
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	// Schemas are registered first, so that the seed events are validated
+	// against them.
+	for _, eventType := range slices.Sorted(maps.Keys(c.seedSchemas)) {
+		err := client.RegisterEventSchema(eventType, c.seedSchemas[eventType])
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(seedEvents) > 0 {
+		_, err := client.WriteEvents(seedEvents, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -143,7 +482,12 @@ func (c *Container) GetBaseURL(ctx context.Context) (*url.URL, error) {
 		return nil, err
 	}
 
-	baseURL, err := url.Parse(fmt.Sprintf("http://%s:%d", host, port))
+	scheme := "http"
+	if c.certificateAuthority != nil {
+		scheme = "https"
+	}
+
+	baseURL, err := url.Parse(fmt.Sprintf("%s://%s:%d", scheme, host, port))
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +495,24 @@ func (c *Container) GetBaseURL(ctx context.Context) (*url.URL, error) {
 	return baseURL, nil
 }
 
+// GetImage returns the image the container was started from.
+func (c *Container) GetImage() (string, error) {
+	if c.container == nil {
+		return "", errors.New("container must be running")
+	}
+
+	return c.image, nil
+}
+
+// GetServerInfo returns the version the server reported after it was started.
+func (c *Container) GetServerInfo() (ServerInfo, error) {
+	if c.container == nil {
+		return ServerInfo{}, errors.New("container must be running")
+	}
+
+	return *c.serverInfo, nil
+}
+
 func (c *Container) GetAPIToken() string {
 	return c.apiToken
 }
@@ -176,6 +538,22 @@ func (c *Container) GetVerificationKey() (*ed25519.PublicKey, error) {
 	return &verificationKey, nil
 }
 
+func (c *Container) GetCACertificate() ([]byte, error) {
+	if c.certificateAuthority == nil {
+		return nil, errors.New("HTTPS not enabled")
+	}
+
+	return c.certificateAuthority.CertificatePEM, nil
+}
+
+func (c *Container) Logs(ctx context.Context) (io.ReadCloser, error) {
+	if c.container == nil {
+		return nil, errors.New("container must be running")
+	}
+
+	return c.container.Logs(ctx)
+}
+
 func (c *Container) IsRunning() bool {
 	return c.container != nil
 }
@@ -191,16 +569,65 @@ func (c *Container) Stop(ctx context.Context) error {
 	}
 
 	c.container = nil
+	c.image = ""
+	c.serverInfo = nil
+	c.removeDataSnapshot()
 	return nil
 }
 
-func (c *Container) GetClient(ctx context.Context) (*Client, error) {
+// Reset restores the container to its initial state, i.e. to an empty data
+// directory that only contains the seed events and schemas. If a data
+// directory is configured, it is restored to the contents it had when the
+// container was started instead. To do so, the server is restarted within the
+// existing container, which keeps the host port, so existing clients keep
+// working.
+func (c *Container) Reset(ctx context.Context) error {
+	if c.container == nil {
+		return errors.New("container must be running")
+	}
+
+	seedEvents, err := c.readSeedEvents()
+	if err != nil {
+		return err
+	}
+
+	err = c.container.Stop(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if c.dataSnapshotPath != "" {
+		dataDirectory, err := filepath.Abs(c.dataDirectory)
+		if err != nil {
+			return err
+		}
+
+		err = internal.RestoreDirectory(c.dataSnapshotPath, dataDirectory)
+		if err != nil {
+			return fmt.Errorf("failed to restore data directory: %w", err)
+		}
+	}
+
+	err = c.container.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.initialize(ctx, seedEvents)
+}
+
+func (c *Container) GetClient(ctx context.Context, options ...ClientOption) (*Client, error) {
 	baseURL, err := c.GetBaseURL(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	client, err := NewClient(baseURL, c.apiToken)
+	var containerOptions []ClientOption
+	if c.certificateAuthority != nil {
+		containerOptions = append(containerOptions, WithCACertificate(c.certificateAuthority.CertificatePEM))
+	}
+
+	client, err := NewClient(baseURL, c.apiToken, append(containerOptions, options...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,66 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestContainerImage(t *testing.T) {
+	t.Run("returns an error for a missing Dockerfile before starting the container", func(t *testing.T) {
+		container := eventsourcingdb.NewContainer().
+			WithImageFromDockerfile(filepath.Join(t.TempDir(), "Dockerfile"))
+
+		err := container.Start(context.Background())
+		assert.Error(t, err)
+		assert.False(t, container.IsRunning())
+	})
+
+	t.Run("returns an error for the image and server info if the container is not running", func(t *testing.T) {
+		container := eventsourcingdb.NewContainer()
+
+		_, err := container.GetImage()
+		assert.Error(t, err)
+
+		_, err = container.GetServerInfo()
+		assert.Error(t, err)
+	})
+
+	t.Run("starts the image from the Dockerfile and reports the server version", func(t *testing.T) {
+		ctx := context.Background()
+
+		container := eventsourcingdb.NewContainer().
+			WithImageFromDockerfile(filepath.Join("..", "docker", "Dockerfile"))
+		err := container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		image, err := container.GetImage()
+		require.NoError(t, err)
+		assert.Equal(t, "thenativeweb/eventsourcingdb:preview", image)
+
+		serverInfo, err := container.GetServerInfo()
+		require.NoError(t, err)
+		assert.NotEmpty(t, serverInfo.RawVersion)
+	})
+
+	t.Run("prefers the image from the environment", func(t *testing.T) {
+		ctx := context.Background()
+
+		t.Setenv("EVENTSOURCINGDB_IMAGE", "thenativeweb/eventsourcingdb:preview")
+
+		container := eventsourcingdb.NewContainer().
+			WithImage("thenativeweb/eventsourcingdb:non-existent")
+		err := container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		image, err := container.GetImage()
+		require.NoError(t, err)
+		assert.Equal(t, "thenativeweb/eventsourcingdb:preview", image)
+	})
+}
@@ -0,0 +1,112 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestContainerOptions(t *testing.T) {
+	t.Run("returns an error for logs if the container is not running", func(t *testing.T) {
+		container := eventsourcingdb.NewContainer()
+
+		_, err := container.Logs(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("keeps data and seeds across restarts with a data directory", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		dataDirectory := filepath.Join(t.TempDir(), "data")
+		seedEvent := eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: "/test",
+			Type:    "io.eventsourcingdb.test",
+			Data:    map[string]any{"value": 23},
+		}
+
+		newContainer := func() *eventsourcingdb.Container {
+			return eventsourcingdb.NewContainer().
+				WithImageTag(imageVersion).
+				WithDataDirectory(dataDirectory).
+				WithSeedEvents(seedEvent)
+		}
+
+		container := newContainer()
+		err = container.Start(ctx)
+		require.NoError(t, err)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{seedEvent}, nil)
+		require.NoError(t, err)
+
+		err = container.Stop(ctx)
+		require.NoError(t, err)
+
+		container = newContainer()
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		client, err = container.GetClient(ctx)
+		require.NoError(t, err)
+
+		eventsRead := 0
+		for _, err := range client.ReadEvents(ctx, "/test", eventsourcingdb.ReadEventsOptions{}) {
+			require.NoError(t, err)
+			eventsRead++
+		}
+		assert.Equal(t, 2, eventsRead)
+	})
+
+	t.Run("passes extra args and environment variables to the server", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithExtraArgs("--non-existent-flag").
+			WithEnv("ESDB_TEST", "true").
+			WithStartupTimeout(5 * time.Second)
+
+		err = container.Start(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("provides the logs of the container", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithLogHandler(eventsourcingdbtest.NewTestLogHandler(t))
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		logs, err := container.Logs(ctx)
+		require.NoError(t, err)
+		defer logs.Close()
+
+		buffer := make([]byte, 1)
+		_, err = io.ReadFull(logs, buffer)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,116 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestContainerReset(t *testing.T) {
+	t.Run("returns an error if the container is not running", func(t *testing.T) {
+		container := eventsourcingdb.NewContainer()
+
+		err := container.Reset(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("resets the container to its seeded state", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		seedEvent := eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: "/test",
+			Type:    "io.eventsourcingdb.test",
+			Data:    map[string]any{"value": 23},
+		}
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithSeedEvents(seedEvent)
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{seedEvent}, nil)
+		require.NoError(t, err)
+
+		baseURL, err := container.GetBaseURL(ctx)
+		require.NoError(t, err)
+
+		err = container.Reset(ctx)
+		require.NoError(t, err)
+		assert.True(t, container.IsRunning())
+
+		// The container keeps its port, so the existing client keeps working.
+		baseURLAfterReset, err := container.GetBaseURL(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, baseURL, baseURLAfterReset)
+
+		eventsRead := 0
+		for _, err := range client.ReadEvents(ctx, "/test", eventsourcingdb.ReadEventsOptions{}) {
+			require.NoError(t, err)
+			eventsRead++
+		}
+		assert.Equal(t, 1, eventsRead)
+	})
+
+	t.Run("restores a configured data directory", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		dataDirectory := t.TempDir()
+		event := eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: "/test",
+			Type:    "io.eventsourcingdb.test",
+			Data:    map[string]any{"value": 23},
+		}
+
+		// The first container leaves an event in the data directory, which
+		// is the state the second container is reset to.
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithDataDirectory(dataDirectory)
+		err = container.Start(ctx)
+		require.NoError(t, err)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{event}, nil)
+		require.NoError(t, err)
+
+		err = container.Stop(ctx)
+		require.NoError(t, err)
+
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		client, err = container.GetClient(ctx)
+		require.NoError(t, err)
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{event}, nil)
+		require.NoError(t, err)
+
+		err = container.Reset(ctx)
+		require.NoError(t, err)
+
+		eventsRead := 0
+		for _, err := range client.ReadEvents(ctx, "/test", eventsourcingdb.ReadEventsOptions{}) {
+			require.NoError(t, err)
+			eventsRead++
+		}
+		assert.Equal(t, 1, eventsRead)
+	})
+}
@@ -0,0 +1,136 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestContainerSeed(t *testing.T) {
+	writeSeedFile := func(t *testing.T, name string, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), name)
+		err := os.WriteFile(path, []byte(content), 0600)
+		require.NoError(t, err)
+
+		return path
+	}
+
+	t.Run("returns an error for an invalid seed file before starting the container", func(t *testing.T) {
+		ctx := context.Background()
+
+		for _, content := range []string{
+			`{"source":"https://www.eventsourcingdb.io",`,
+			`[{"source":"https://www.eventsourcingdb.io","unknown":true}]`,
+		} {
+			container := eventsourcingdb.NewContainer().
+				WithSeedFile(writeSeedFile(t, "seed.ndjson", content))
+
+			err := container.Start(ctx)
+			assert.Error(t, err, content)
+			assert.False(t, container.IsRunning())
+		}
+	})
+
+	t.Run("returns an error for a missing seed file", func(t *testing.T) {
+		ctx := context.Background()
+
+		container := eventsourcingdb.NewContainer().
+			WithSeedFile(filepath.Join(t.TempDir(), "non-existent.ndjson"))
+
+		err := container.Start(ctx)
+		assert.Error(t, err)
+		assert.False(t, container.IsRunning())
+	})
+
+	t.Run("seeds events and schemas", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		ndjsonFile := writeSeedFile(t, "seed.ndjson",
+			`{"source":"https://www.eventsourcingdb.io","subject":"/test","type":"io.eventsourcingdb.test","data":{"value":2}}`+"\n"+
+				`{"source":"https://www.eventsourcingdb.io","subject":"/test","type":"io.eventsourcingdb.test","data":{"value":3}}`+"\n",
+		)
+		jsonFile := writeSeedFile(t, "seed.json",
+			`[{"source":"https://www.eventsourcingdb.io","subject":"/test","type":"io.eventsourcingdb.test","data":{"value":4}}]`,
+		)
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithSeedSchemas(map[string]map[string]any{
+				"io.eventsourcingdb.test": {
+					"type": "object",
+					"properties": map[string]any{
+						"value": map[string]any{"type": "number"},
+					},
+					"required":             []any{"value"},
+					"additionalProperties": false,
+				},
+			}).
+			WithSeedEvents(eventsourcingdb.EventCandidate{
+				Source:  "https://www.eventsourcingdb.io",
+				Subject: "/test",
+				Type:    "io.eventsourcingdb.test",
+				Data:    map[string]any{"value": 1},
+			}).
+			WithSeedFile(ndjsonFile).
+			WithSeedFile(jsonFile)
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+
+		eventsRead := []eventsourcingdb.Event{}
+		for event, err := range client.ReadEvents(ctx, "/test", eventsourcingdb.ReadEventsOptions{}) {
+			require.NoError(t, err)
+			eventsRead = append(eventsRead, event)
+		}
+
+		require.Len(t, eventsRead, 4)
+		for i, event := range eventsRead {
+			assert.JSONEq(t, fmt.Sprintf(`{"value":%d}`, i+1), string(event.Data))
+		}
+
+		eventType, err := client.ReadEventType("io.eventsourcingdb.test")
+		require.NoError(t, err)
+		assert.NotNil(t, eventType.Schema)
+	})
+
+	t.Run("returns an error from start if seeding fails", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithSeedSchemas(map[string]map[string]any{
+				"io.eventsourcingdb.test": {
+					"type":     "object",
+					"required": []any{"value"},
+				},
+			}).
+			WithSeedEvents(eventsourcingdb.EventCandidate{
+				Source:  "https://www.eventsourcingdb.io",
+				Subject: "/test",
+				Type:    "io.eventsourcingdb.test",
+				Data:    map[string]any{},
+			})
+
+		err = container.Start(ctx)
+		assert.Error(t, err)
+		assert.False(t, container.IsRunning())
+	})
+}
@@ -0,0 +1,114 @@
+package eventsourcingdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// embeddedJournal appends every change to a file as one NDJSON line, using the
+// same line format as the streams of the HTTP API. Each append is synced to
+// disk before the change becomes visible.
+type embeddedJournal struct {
+	file *os.File
+}
+
+type embeddedEventSchema struct {
+	EventType string         `json:"eventType"`
+	Schema    map[string]any `json:"schema"`
+}
+
+func (j *embeddedJournal) AppendEvents(events []internal.CloudEvent) error {
+	var buffer bytes.Buffer
+	encoder := internal.NewJSONEncoder(&buffer)
+
+	for _, event := range events {
+		err := encoder.Encode(map[string]any{
+			"type":    "event",
+			"payload": event,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return j.append(buffer.Bytes())
+}
+
+func (j *embeddedJournal) AppendEventSchema(eventType string, schema map[string]any) error {
+	line, err := json.Marshal(map[string]any{
+		"type": "eventSchema",
+		"payload": embeddedEventSchema{
+			EventType: eventType,
+			Schema:    schema,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return j.append(append(line, '\n'))
+}
+
+func (j *embeddedJournal) append(lines []byte) error {
+	return appendLines(j.file, lines)
+}
+
+// appendLines writes the lines and syncs them to disk. If either fails, the
+// file is truncated to its previous size, so that a partially written line does
+// not get glued to the next append.
+func appendLines(file *os.File, lines []byte) error {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(lines)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err != nil {
+		return errors.Join(err, file.Truncate(fileInfo.Size()))
+	}
+
+	return nil
+}
+
+// truncateTornTail removes a partial last line, which is left behind if the
+// process crashes while appending. Since the change was never acknowledged,
+// dropping it is safe.
+func truncateTornTail(file *os.File) error {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	size := fileInfo.Size()
+	chunk := make([]byte, 4096)
+	end := size
+
+	for end > 0 {
+		start := max(0, end-int64(len(chunk)))
+		n, err := file.ReadAt(chunk[:end-start], start)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		index := bytes.LastIndexByte(chunk[:n], '\n')
+		if index >= 0 {
+			end = start + int64(index) + 1
+			break
+		}
+		end = start
+	}
+
+	if end == size {
+		return nil
+	}
+
+	return file.Truncate(end)
+}
@@ -0,0 +1,442 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"sync"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+var ErrEmbeddedStoreClosed = errors.New("embedded store is closed")
+
+// EmbeddedStore is an event store that runs in-process, without a database
+// server, and persists events to an append-only file. It implements the same
+// EventStore interface as Client, and computes hashes the same way as
+// EventSourcingDB. It does not support EventQL, and does not validate event
+// data against registered schemas.
+type EmbeddedStore struct {
+	store     *store.Store
+	file      *os.File
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+var _ EventStore = (*EmbeddedStore)(nil)
+
+// OpenEmbeddedStore opens the store persisted in the file at the given path,
+// or creates it if the file does not exist. The events in the file are
+// verified while loading, so a corrupted file is rejected. A partial last line,
+// as left behind by a crash while writing, is removed.
+func OpenEmbeddedStore(path string) (*EmbeddedStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = truncateTornTail(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to load embedded store: %w", err)
+	}
+
+	eventStore := store.New()
+
+	for line, err := range internal.UnmarshalNDJSON(context.Background(), file) {
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to load embedded store: %w", err)
+		}
+
+		err = loadEmbeddedStoreLine(eventStore, line)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to load embedded store: %w", err)
+		}
+	}
+
+	eventStore.SetJournal(&embeddedJournal{file: file})
+
+	return &EmbeddedStore{
+		store:  eventStore,
+		file:   file,
+		closed: make(chan struct{}),
+	}, nil
+}
+
+func loadEmbeddedStoreLine(eventStore *store.Store, line internal.Line) error {
+	switch line.Type {
+	case "event":
+		var cloudEvent internal.CloudEvent
+		err := json.Unmarshal(line.Payload, &cloudEvent)
+		if err != nil {
+			return err
+		}
+
+		return eventStore.LoadEvent(cloudEvent)
+	case "eventSchema":
+		var eventSchema embeddedEventSchema
+		err := json.Unmarshal(line.Payload, &eventSchema)
+		if err != nil {
+			return err
+		}
+
+		return eventStore.LoadEventSchema(eventSchema.EventType, eventSchema.Schema)
+	default:
+		return fmt.Errorf("unsupported line type: %s", line.Type)
+	}
+}
+
+// Close closes the file of the store, and ends all running ObserveEvents
+// iterators. Afterwards, all calls fail with ErrEmbeddedStoreClosed.
+func (s *EmbeddedStore) Close() error {
+	err := ErrEmbeddedStoreClosed
+	s.closeOnce.Do(func() {
+		s.store.SetJournal(closedEmbeddedJournal{})
+		close(s.closed)
+		err = s.file.Close()
+	})
+
+	return err
+}
+
+func (s *EmbeddedStore) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *EmbeddedStore) WriteEvents(events []EventCandidate, preconditions []Precondition) ([]Event, error) {
+	if s.isClosed() {
+		return nil, ErrEmbeddedStoreClosed
+	}
+
+	candidates := make([]store.EventCandidate, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, store.EventCandidate{
+			Source:      event.Source,
+			Subject:     event.Subject,
+			Type:        event.Type,
+			Data:        data,
+			TraceParent: event.TraceParent,
+			TraceState:  event.TraceState,
+		})
+	}
+
+	storePreconditions := make([]store.Precondition, 0, len(preconditions))
+	for _, precondition := range preconditions {
+		storePrecondition, err := getStorePrecondition(precondition)
+		if err != nil {
+			return nil, err
+		}
+		storePreconditions = append(storePreconditions, storePrecondition)
+	}
+
+	cloudEvents, err := s.store.WriteEvents(candidates, storePreconditions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write events: %w", err)
+	}
+
+	writtenEvents := make([]Event, 0, len(cloudEvents))
+	for _, cloudEvent := range cloudEvents {
+		writtenEvent, err := NewEventFromCloudEvent(cloudEvent)
+		if err != nil {
+			return nil, err
+		}
+		writtenEvents = append(writtenEvents, writtenEvent)
+	}
+
+	return writtenEvents, nil
+}
+
+func getStorePrecondition(precondition Precondition) (store.Precondition, error) {
+	switch precondition := precondition.(type) {
+	case isSubjectPristinePrecondition:
+		return store.Precondition{
+			Type:    "isSubjectPristine",
+			Payload: store.PreconditionPayload{Subject: precondition.Subject()},
+		}, nil
+	case isSubjectPopulatedPrecondition:
+		return store.Precondition{
+			Type:    "isSubjectPopulated",
+			Payload: store.PreconditionPayload{Subject: precondition.Subject()},
+		}, nil
+	case isSubjectOnEventIDPrecondition:
+		return store.Precondition{
+			Type:    "isSubjectOnEventId",
+			Payload: store.PreconditionPayload{Subject: precondition.Subject(), EventID: precondition.EventID()},
+		}, nil
+	case isEventQLQueryTruePrecondition:
+		return store.Precondition{
+			Type:    "isEventQlQueryTrue",
+			Payload: store.PreconditionPayload{Query: precondition.Query()},
+		}, nil
+	default:
+		return store.Precondition{}, fmt.Errorf("unsupported predicate type: %T", precondition)
+	}
+}
+
+func getStoreBound(bound *Bound) *store.Bound {
+	if bound == nil {
+		return nil
+	}
+
+	return &store.Bound{
+		ID:   bound.ID,
+		Type: string(bound.Type),
+	}
+}
+
+func (s *EmbeddedStore) ReadEvents(
+	ctx context.Context,
+	subject string,
+	options ReadEventsOptions,
+) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		if s.isClosed() {
+			yield(Event{}, ErrEmbeddedStoreClosed)
+			return
+		}
+
+		storeOptions := store.ReadEventsOptions{
+			Recursive:  options.Recursive,
+			LowerBound: getStoreBound(options.LowerBound),
+			UpperBound: getStoreBound(options.UpperBound),
+		}
+		if options.Order != nil {
+			storeOptions.Order = string(*options.Order)
+		}
+		if options.FromLatestEvent != nil {
+			if options.FromLatestEvent.IfEventIsMissing != ReadNothingIfEventIsMissing &&
+				options.FromLatestEvent.IfEventIsMissing != ReadEverythingIfEventIsMissing {
+				yield(Event{}, fmt.Errorf("unsupported if event is missing '%s'", options.FromLatestEvent.IfEventIsMissing))
+				return
+			}
+
+			storeOptions.FromLatestEvent = &store.FromLatestEvent{
+				Subject:          options.FromLatestEvent.Subject,
+				Type:             options.FromLatestEvent.Type,
+				IfEventIsMissing: string(options.FromLatestEvent.IfEventIsMissing),
+			}
+		}
+
+		cloudEvents, err := s.store.ReadEvents(subject, storeOptions)
+		if err != nil {
+			yield(Event{}, fmt.Errorf("failed to read events: %w", err))
+			return
+		}
+
+		for _, cloudEvent := range cloudEvents {
+			if ctx.Err() != nil {
+				return
+			}
+
+			event, err := NewEventFromCloudEvent(cloudEvent)
+			if err != nil {
+				yield(Event{}, err)
+				return
+			}
+
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *EmbeddedStore) ObserveEvents(
+	ctx context.Context,
+	subject string,
+	options ObserveEventsOptions,
+) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		if s.isClosed() {
+			yield(Event{}, ErrEmbeddedStoreClosed)
+			return
+		}
+
+		storeOptions := store.ReadEventsOptions{
+			Recursive:  options.Recursive,
+			LowerBound: getStoreBound(options.LowerBound),
+		}
+		if options.FromLatestEvent != nil {
+			if options.FromLatestEvent.IfEventIsMissing != WaitForEventIfEventIsMissing &&
+				options.FromLatestEvent.IfEventIsMissing != ObserveEverythingIfEventIsMissing {
+				yield(Event{}, fmt.Errorf("unsupported if event is missing '%s'", options.FromLatestEvent.IfEventIsMissing))
+				return
+			}
+
+			storeOptions.FromLatestEvent = &store.FromLatestEvent{
+				Subject:          options.FromLatestEvent.Subject,
+				Type:             options.FromLatestEvent.Type,
+				IfEventIsMissing: string(options.FromLatestEvent.IfEventIsMissing),
+			}
+		}
+
+		for {
+			changed := s.store.Watch()
+
+			cloudEvents, err := s.store.ReadEvents(subject, storeOptions)
+			if err != nil {
+				yield(Event{}, fmt.Errorf("failed to observe events: %w", err))
+				return
+			}
+
+			for _, cloudEvent := range cloudEvents {
+				event, err := NewEventFromCloudEvent(cloudEvent)
+				if err != nil {
+					yield(Event{}, err)
+					return
+				}
+
+				if !yield(event, nil) {
+					return
+				}
+			}
+
+			// Once events have been yielded, observing continues right
+			// after the last one, no matter how it started.
+			if len(cloudEvents) > 0 {
+				storeOptions = store.ReadEventsOptions{
+					Recursive: options.Recursive,
+					LowerBound: &store.Bound{
+						ID:   cloudEvents[len(cloudEvents)-1].ID,
+						Type: string(BoundTypeExclusive),
+					},
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.closed:
+				return
+			case <-changed:
+			}
+		}
+	}
+}
+
+func (s *EmbeddedStore) ReadSubjects(
+	ctx context.Context,
+	baseSubject string,
+) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if s.isClosed() {
+			yield("", ErrEmbeddedStoreClosed)
+			return
+		}
+
+		subjects, err := s.store.ReadSubjects(baseSubject)
+		if err != nil {
+			yield("", fmt.Errorf("failed to read subjects: %w", err))
+			return
+		}
+
+		for _, subject := range subjects {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !yield(subject, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *EmbeddedStore) RunEventQLQuery(
+	ctx context.Context,
+	query string,
+) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		if s.isClosed() {
+			yield(nil, ErrEmbeddedStoreClosed)
+			return
+		}
+
+		_, err := s.store.RunEventQLQuery(query)
+		yield(nil, fmt.Errorf("failed to run EventQL query: %w", err))
+	}
+}
+
+func (s *EmbeddedStore) RegisterEventSchema(eventType string, schema map[string]any) error {
+	if s.isClosed() {
+		return ErrEmbeddedStoreClosed
+	}
+
+	err := s.store.RegisterEventSchema(eventType, schema)
+	if err != nil {
+		return fmt.Errorf("failed to register event schema: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EmbeddedStore) ReadEventType(eventType string) (EventType, error) {
+	if s.isClosed() {
+		return EventType{}, ErrEmbeddedStoreClosed
+	}
+
+	streamEventType, err := s.store.ReadEventType(eventType)
+	if err != nil {
+		return EventType{}, fmt.Errorf("failed to read event type: %w", err)
+	}
+
+	return EventType{
+		EventType: streamEventType.EventType,
+		IsPhantom: streamEventType.IsPhantom,
+		Schema:    streamEventType.Schema,
+	}, nil
+}
+
+func (s *EmbeddedStore) ReadEventTypes(
+	ctx context.Context,
+) iter.Seq2[EventType, error] {
+	return func(yield func(EventType, error) bool) {
+		if s.isClosed() {
+			yield(EventType{}, ErrEmbeddedStoreClosed)
+			return
+		}
+
+		for _, streamEventType := range s.store.ReadEventTypes() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			eventType := EventType{
+				EventType: streamEventType.EventType,
+				IsPhantom: streamEventType.IsPhantom,
+				Schema:    streamEventType.Schema,
+			}
+			if !yield(eventType, nil) {
+				return
+			}
+		}
+	}
+}
+
+// closedEmbeddedJournal rejects all changes, so that writes racing with Close
+// can not reach the closed file.
+type closedEmbeddedJournal struct{}
+
+func (closedEmbeddedJournal) AppendEvents([]internal.CloudEvent) error {
+	return ErrEmbeddedStoreClosed
+}
+
+func (closedEmbeddedJournal) AppendEventSchema(string, map[string]any) error {
+	return ErrEmbeddedStoreClosed
+}
@@ -0,0 +1,277 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestEmbeddedStore(t *testing.T) {
+	type EventData struct {
+		Value int `json:"value"`
+	}
+
+	newEventCandidate := func(subject string, value int) eventsourcingdb.EventCandidate {
+		return eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: subject,
+			Type:    "io.eventsourcingdb.test",
+			Data: EventData{
+				Value: value,
+			},
+		}
+	}
+
+	openStore := func(t *testing.T, path string) *eventsourcingdb.EmbeddedStore {
+		t.Helper()
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+
+		return store
+	}
+
+	readEvents := func(t *testing.T, eventStore eventsourcingdb.Reader) []eventsourcingdb.Event {
+		t.Helper()
+
+		events := []eventsourcingdb.Event{}
+		for event, err := range eventStore.ReadEvents(
+			context.Background(),
+			"/",
+			eventsourcingdb.ReadEventsOptions{Recursive: true},
+		) {
+			require.NoError(t, err)
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	t.Run("writes and reads events with a valid hash chain", func(t *testing.T) {
+		store := openStore(t, filepath.Join(t.TempDir(), "events.ndjson"))
+
+		writtenEvents, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{
+				newEventCandidate("/test", 23),
+				newEventCandidate("/test", 42),
+			},
+			nil,
+		)
+		require.NoError(t, err)
+		require.Len(t, writtenEvents, 2)
+
+		assert.Equal(t, "0", writtenEvents[0].ID)
+		assert.Equal(t, "1", writtenEvents[1].ID)
+		assert.Equal(t, writtenEvents[0].Hash, writtenEvents[1].PredecessorHash)
+
+		events := readEvents(t, store)
+		require.Len(t, events, 2)
+		assert.Equal(t, writtenEvents, events)
+
+		for _, event := range events {
+			assert.NoError(t, event.VerifyHash())
+		}
+	})
+
+	t.Run("persists events and schemas across reopening", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.ndjson")
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(path)
+		require.NoError(t, err)
+
+		writtenEvents, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			nil,
+		)
+		require.NoError(t, err)
+
+		err = store.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{"type": "object"})
+		require.NoError(t, err)
+
+		err = store.Close()
+		require.NoError(t, err)
+
+		reopenedStore := openStore(t, path)
+
+		assert.Equal(t, writtenEvents, readEvents(t, reopenedStore))
+
+		eventType, err := reopenedStore.ReadEventType("io.eventsourcingdb.test")
+		require.NoError(t, err)
+		require.NotNil(t, eventType.Schema)
+		assert.Equal(t, map[string]any{"type": "object"}, *eventType.Schema)
+
+		nextEvents, err := reopenedStore.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 42)},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "1", nextEvents[0].ID)
+		assert.Equal(t, writtenEvents[0].Hash, nextEvents[0].PredecessorHash)
+	})
+
+	t.Run("removes a partial last line left behind by a crash", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.ndjson")
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(path)
+		require.NoError(t, err)
+
+		writtenEvents, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			nil,
+		)
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+		require.NoError(t, err)
+		// The partial line is longer than the chunks the file is read in.
+		_, err = file.WriteString(`{"type":"event","payload":{"specversion":"1.0","id":"1","data":"` + strings.Repeat("x", 10000))
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		reopenedStore, err := eventsourcingdb.OpenEmbeddedStore(path)
+		require.NoError(t, err)
+		assert.Equal(t, writtenEvents, readEvents(t, reopenedStore))
+
+		nextEvents, err := reopenedStore.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 42)},
+			nil,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "1", nextEvents[0].ID)
+		require.NoError(t, reopenedStore.Close())
+
+		finalStore := openStore(t, path)
+		assert.Equal(t, append(writtenEvents, nextEvents...), readEvents(t, finalStore))
+	})
+
+	t.Run("rejects a file that has been tampered with", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.ndjson")
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(path)
+		require.NoError(t, err)
+
+		_, err = store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			nil,
+		)
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		tamperedContent := strings.Replace(string(content), `"value":23`, `"value":42`, 1)
+		require.NotEqual(t, string(content), tamperedContent)
+		require.NoError(t, os.WriteFile(path, []byte(tamperedContent), 0600))
+
+		_, err = eventsourcingdb.OpenEmbeddedStore(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("enforces preconditions", func(t *testing.T) {
+		store := openStore(t, filepath.Join(t.TempDir(), "events.ndjson"))
+
+		writtenEvents, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			[]eventsourcingdb.Precondition{eventsourcingdb.NewIsSubjectPristinePrecondition("/test")},
+		)
+		require.NoError(t, err)
+
+		_, err = store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 42)},
+			[]eventsourcingdb.Precondition{eventsourcingdb.NewIsSubjectPristinePrecondition("/test")},
+		)
+		assert.Error(t, err)
+
+		_, err = store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 42)},
+			[]eventsourcingdb.Precondition{eventsourcingdb.NewIsSubjectOnEventIDPrecondition("/test", writtenEvents[0].ID)},
+		)
+		assert.NoError(t, err)
+	})
+
+	t.Run("observes existing and new events", func(t *testing.T) {
+		store := openStore(t, filepath.Join(t.TempDir(), "events.ndjson"))
+
+		_, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			nil,
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		time.AfterFunc(50*time.Millisecond, func() {
+			store.WriteEvents(
+				[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 42)},
+				nil,
+			)
+		})
+
+		eventsObserved := []eventsourcingdb.Event{}
+		for event, err := range store.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+			eventsObserved = append(eventsObserved, event)
+
+			if len(eventsObserved) == 2 {
+				break
+			}
+		}
+
+		require.Len(t, eventsObserved, 2)
+		assert.Equal(t, "0", eventsObserved[0].ID)
+		assert.Equal(t, "1", eventsObserved[1].ID)
+	})
+
+	t.Run("ends observing when the store is closed", func(t *testing.T) {
+		store := openStore(t, filepath.Join(t.TempDir(), "events.ndjson"))
+
+		time.AfterFunc(50*time.Millisecond, func() { store.Close() })
+
+		for _, err := range store.ObserveEvents(context.Background(), "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+		}
+
+		_, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/test", 23)},
+			nil,
+		)
+		assert.ErrorIs(t, err, eventsourcingdb.ErrEmbeddedStoreClosed)
+	})
+
+	t.Run("reads subjects and event types", func(t *testing.T) {
+		store := openStore(t, filepath.Join(t.TempDir(), "events.ndjson"))
+
+		_, err := store.WriteEvents(
+			[]eventsourcingdb.EventCandidate{newEventCandidate("/books/42", 23)},
+			nil,
+		)
+		require.NoError(t, err)
+
+		subjects := []string{}
+		for subject, err := range store.ReadSubjects(context.Background(), "/") {
+			require.NoError(t, err)
+			subjects = append(subjects, subject)
+		}
+		assert.Equal(t, []string{"/", "/books", "/books/42"}, subjects)
+
+		eventTypes := []eventsourcingdb.EventType{}
+		for eventType, err := range store.ReadEventTypes(context.Background()) {
+			require.NoError(t, err)
+			eventTypes = append(eventTypes, eventType)
+		}
+		require.Len(t, eventTypes, 1)
+		assert.Equal(t, "io.eventsourcingdb.test", eventTypes[0].EventType)
+		assert.False(t, eventTypes[0].IsPhantom)
+	})
+}
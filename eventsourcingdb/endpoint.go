@@ -0,0 +1,63 @@
+package eventsourcingdb
+
+import (
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type endpoint struct {
+	baseURL *url.URL
+	// serverInfo is tracked per endpoint, since the servers behind the
+	// endpoints of a client may run different versions, e.g. while they are
+	// upgraded one after another.
+	serverInfo atomic.Pointer[ServerInfo]
+
+	mutex       sync.Mutex
+	isHealthy   bool
+	lastChecked time.Time
+}
+
+func newEndpoint(baseURL *url.URL) *endpoint {
+	return &endpoint{
+		baseURL:   baseURL,
+		isHealthy: true,
+	}
+}
+
+func (e *endpoint) getIsHealthy() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.isHealthy
+}
+
+func (e *endpoint) setIsHealthy(isHealthy bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.isHealthy = isHealthy
+	e.lastChecked = time.Now()
+
+	// The server may be replaced, e.g. by a newer version, while the endpoint
+	// is unreachable, so its version has to be learned again.
+	if !isHealthy {
+		e.serverInfo.Store(nil)
+	}
+}
+
+// claimHealthCheck reports whether an unhealthy endpoint is due for another
+// health check. It resets the check time right away, so that concurrent
+// requests do not all ping the same endpoint at once.
+func (e *endpoint) claimHealthCheck(interval time.Duration) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.isHealthy || time.Since(e.lastChecked) < interval {
+		return false
+	}
+
+	e.lastChecked = time.Now()
+	return true
+}
@@ -0,0 +1,8 @@
+package eventsourcingdb
+
+type EndpointSelection string
+
+const (
+	EndpointSelectionPrimarySecondary EndpointSelection = "primary-secondary"
+	EndpointSelectionRoundRobin       EndpointSelection = "round-robin"
+)
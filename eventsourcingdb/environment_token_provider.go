@@ -0,0 +1,30 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+type environmentTokenProvider struct {
+	variableName string
+}
+
+func NewEnvironmentTokenProvider(variableName string) TokenProvider {
+	return environmentTokenProvider{
+		variableName,
+	}
+}
+
+func (p environmentTokenProvider) GetToken(ctx context.Context) (string, error) {
+	token, ok := os.LookupEnv(p.variableName)
+	if !ok || token == "" {
+		return "", fmt.Errorf("environment variable '%s' must be set", p.variableName)
+	}
+
+	return token, nil
+}
+
+func (p environmentTokenProvider) RefreshToken(ctx context.Context) (string, error) {
+	return p.GetToken(ctx)
+}
@@ -0,0 +1,40 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+)
+
+type Writer interface {
+	WriteEvents(events []EventCandidate, preconditions []Precondition) ([]Event, error)
+}
+
+type Reader interface {
+	ReadEvents(ctx context.Context, subject string, options ReadEventsOptions) iter.Seq2[Event, error]
+	ReadSubjects(ctx context.Context, baseSubject string) iter.Seq2[string, error]
+}
+
+type Observer interface {
+	ObserveEvents(ctx context.Context, subject string, options ObserveEventsOptions) iter.Seq2[Event, error]
+}
+
+type Querier interface {
+	RunEventQLQuery(ctx context.Context, query string) iter.Seq2[json.RawMessage, error]
+}
+
+type SchemaManager interface {
+	RegisterEventSchema(eventType string, schema map[string]any) error
+	ReadEventType(eventType string) (EventType, error)
+	ReadEventTypes(ctx context.Context) iter.Seq2[EventType, error]
+}
+
+type EventStore interface {
+	Writer
+	Reader
+	Observer
+	Querier
+	SchemaManager
+}
+
+var _ EventStore = (*Client)(nil)
@@ -0,0 +1,265 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+type failoverTestServer struct {
+	baseURL      *url.URL
+	requests     atomic.Int32
+	isDown       atomic.Bool
+	abortStreams atomic.Bool
+}
+
+func newFailoverTestServer(t *testing.T) *failoverTestServer {
+	t.Helper()
+
+	testServer := &failoverTestServer{}
+
+	testServer.baseURL = newStubServer(t, stubServerOptions{
+		Routes: map[string]http.HandlerFunc{
+			"/api/v1/write-events": func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[]`)
+			},
+			"/api/v1/read-subjects": func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"type":"subject","payload":{"subject":"/"}}`+"\n")
+			},
+			"/api/v1/observe-events": func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				fmt.Fprint(w, `{"type":"heartbeat","payload":{}}`+"\n")
+				w.(http.Flusher).Flush()
+				if testServer.abortStreams.Load() {
+					panic(http.ErrAbortHandler)
+				}
+				<-r.Context().Done()
+			},
+		},
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if testServer.isDown.Load() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				testServer.requests.Add(1)
+				next.ServeHTTP(w, r)
+			})
+		},
+	})
+
+	return testServer
+}
+
+func newUnreachableURL(t *testing.T) *url.URL {
+	t.Helper()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	return baseURL
+}
+
+func TestFailover(t *testing.T) {
+	readSubjects := func(t *testing.T, client *eventsourcingdb.Client) {
+		t.Helper()
+
+		for _, err := range client.ReadSubjects(context.Background(), "/") {
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("fails over to the secondary endpoint if the primary is unreachable", func(t *testing.T) {
+		secondary := newFailoverTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			newUnreachableURL(t),
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+
+		_, err = client.WriteEvents(nil, nil)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(3), secondary.requests.Load())
+	})
+
+	t.Run("fails over reads if the primary is unavailable", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+		primary.isDown.Store(true)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+		)
+		require.NoError(t, err)
+
+		readSubjects(t, client)
+		assert.Equal(t, int32(1), secondary.requests.Load())
+	})
+
+	t.Run("does not fail over writes if the primary responds with an error", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+		primary.isDown.Store(true)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+		)
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents(nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(0), secondary.requests.Load())
+	})
+
+	t.Run("returns to the primary endpoint once it is healthy again", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+		primary.isDown.Store(true)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+			eventsourcingdb.WithHealthCheckInterval(50*time.Millisecond),
+		)
+		require.NoError(t, err)
+
+		readSubjects(t, client)
+		assert.Equal(t, int32(1), secondary.requests.Load())
+
+		primary.isDown.Store(false)
+		readSubjects(t, client)
+		assert.Equal(t, int32(2), secondary.requests.Load())
+
+		time.Sleep(100 * time.Millisecond)
+
+		// The first request after the interval pings the primary endpoint,
+		// and, since the ping succeeds, uses it again.
+		readSubjects(t, client)
+		assert.Equal(t, int32(2), secondary.requests.Load())
+		assert.Equal(t, int32(2), primary.requests.Load())
+	})
+
+	t.Run("distributes reads in round robin order", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+			eventsourcingdb.WithEndpointSelection(eventsourcingdb.EndpointSelectionRoundRobin),
+		)
+		require.NoError(t, err)
+
+		for range 4 {
+			readSubjects(t, client)
+		}
+		assert.Equal(t, int32(2), primary.requests.Load())
+		assert.Equal(t, int32(2), secondary.requests.Load())
+
+		for range 2 {
+			_, err = client.WriteEvents(nil, nil)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(4), primary.requests.Load())
+		assert.Equal(t, int32(2), secondary.requests.Load())
+	})
+
+	t.Run("keeps observing on the same endpoint", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+			eventsourcingdb.WithEndpointSelection(eventsourcingdb.EndpointSelectionRoundRobin),
+		)
+		require.NoError(t, err)
+
+		observe := func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			for _, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+				require.NoError(t, err)
+			}
+		}
+
+		for range 3 {
+			observe()
+		}
+
+		assert.Equal(t, int32(3), primary.requests.Load()+secondary.requests.Load())
+		assert.True(t, primary.requests.Load() == 3 || secondary.requests.Load() == 3)
+	})
+
+	t.Run("moves observing to another endpoint after the stream failed", func(t *testing.T) {
+		primary := newFailoverTestServer(t)
+		secondary := newFailoverTestServer(t)
+		primary.abortStreams.Store(true)
+
+		client, err := eventsourcingdb.NewClient(
+			primary.baseURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondary.baseURL),
+		)
+		require.NoError(t, err)
+
+		var streamErr error
+		for _, err := range client.ObserveEvents(context.Background(), "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			streamErr = err
+		}
+		require.Error(t, streamErr)
+		assert.Equal(t, int32(1), primary.requests.Load())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		for _, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), primary.requests.Load())
+		assert.Equal(t, int32(1), secondary.requests.Load())
+	})
+
+	t.Run("returns an error for an invalid failover URL", func(t *testing.T) {
+		primaryURL, err := url.Parse("http://localhost:3000")
+		require.NoError(t, err)
+
+		for _, rawFailoverURL := range []string{"http://localhost:3001/api", "unix:///var/run/esdb.sock"} {
+			failoverURL, err := url.Parse(rawFailoverURL)
+			require.NoError(t, err)
+
+			_, err = eventsourcingdb.NewClient(primaryURL, "secret", eventsourcingdb.WithFailoverURLs(failoverURL))
+			assert.Error(t, err, rawFailoverURL)
+		}
+	})
+}
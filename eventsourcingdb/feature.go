@@ -0,0 +1,37 @@
+package eventsourcingdb
+
+// Feature describes an API capability that is only provided by servers from a
+// specific version on.
+type Feature struct {
+	Name           string
+	MinimumVersion ServerVersion
+}
+
+// The features below are provided by every supported server version. Features
+// introduced by later server versions are added with the version that
+// introduced them, so that they can be gated using ServerInfo.Supports.
+var (
+	FeatureEventQL = Feature{
+		Name:           "eventql",
+		MinimumVersion: ServerVersion{Major: 1},
+	}
+	FeatureEventSchemas = Feature{
+		Name:           "event-schemas",
+		MinimumVersion: ServerVersion{Major: 1},
+	}
+	FeatureObserveEvents = Feature{
+		Name:           "observe-events",
+		MinimumVersion: ServerVersion{Major: 1},
+	}
+)
+
+// Supports reports whether the server provides the given feature. Servers that
+// do not report a semantic version are assumed to be up to date, and hence to
+// support every feature.
+func (i ServerInfo) Supports(feature Feature) bool {
+	if i.Version == nil {
+		return true
+	}
+
+	return i.Version.Compare(feature.MinimumVersion) >= 0
+}
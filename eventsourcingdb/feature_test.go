@@ -0,0 +1,61 @@
+package eventsourcingdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestServerInfoSupports(t *testing.T) {
+	newServerInfo := func(version eventsourcingdb.ServerVersion) eventsourcingdb.ServerInfo {
+		return eventsourcingdb.ServerInfo{
+			RawVersion: version.String(),
+			Version:    &version,
+		}
+	}
+
+	upcomingFeature := eventsourcingdb.Feature{
+		Name:           "upcoming",
+		MinimumVersion: eventsourcingdb.ServerVersion{Major: 1, Minor: 5},
+	}
+
+	for _, feature := range []eventsourcingdb.Feature{
+		eventsourcingdb.FeatureEventQL,
+		eventsourcingdb.FeatureEventSchemas,
+		eventsourcingdb.FeatureObserveEvents,
+		upcomingFeature,
+	} {
+		t.Run("gates "+feature.Name+" on its minimum version", func(t *testing.T) {
+			minimumVersion := feature.MinimumVersion
+			assert.True(t, newServerInfo(minimumVersion).Supports(feature))
+
+			newerVersion := minimumVersion
+			newerVersion.Minor++
+			assert.True(t, newServerInfo(newerVersion).Supports(feature))
+
+			olderVersion := minimumVersion
+			olderVersion.PreRelease = "rc.1"
+			assert.False(t, newServerInfo(olderVersion).Supports(feature))
+		})
+	}
+
+	t.Run("supports every feature with a version that is not semantic", func(t *testing.T) {
+		serverInfo := eventsourcingdb.ServerInfo{RawVersion: "preview"}
+
+		assert.True(t, serverInfo.Supports(upcomingFeature))
+	})
+
+	t.Run("every supported server version provides the predefined features", func(t *testing.T) {
+		minimumVersion, err := eventsourcingdb.ParseServerVersion(eventsourcingdb.MinimumSupportedServerVersion)
+		assert.NoError(t, err)
+
+		for _, feature := range []eventsourcingdb.Feature{
+			eventsourcingdb.FeatureEventQL,
+			eventsourcingdb.FeatureEventSchemas,
+			eventsourcingdb.FeatureObserveEvents,
+		} {
+			assert.True(t, newServerInfo(minimumVersion).Supports(feature), feature.Name)
+		}
+	})
+}
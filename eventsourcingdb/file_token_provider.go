@@ -0,0 +1,74 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type fileTokenProvider struct {
+	path string
+
+	mutex   sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func NewFileTokenProvider(path string) TokenProvider {
+	return &fileTokenProvider{
+		path: path,
+	}
+}
+
+func (p *fileTokenProvider) GetToken(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Checking the file's metadata on every call is cheap compared to the
+	// HTTP request that follows, and it lets a rotated token take effect
+	// immediately without a background goroutine watching the file.
+	fileInfo, err := os.Stat(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	if p.token != "" && fileInfo.ModTime().Equal(p.modTime) && fileInfo.Size() == p.size {
+		return p.token, nil
+	}
+
+	return p.readToken(fileInfo)
+}
+
+func (p *fileTokenProvider) RefreshToken(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	fileInfo, err := os.Stat(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	return p.readToken(fileInfo)
+}
+
+func (p *fileTokenProvider) readToken(fileInfo os.FileInfo) (string, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file '%s' must not be empty", p.path)
+	}
+
+	p.token = token
+	p.modTime = fileInfo.ModTime()
+	p.size = fileInfo.Size()
+
+	return token, nil
+}
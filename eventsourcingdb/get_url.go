@@ -2,13 +2,13 @@ package eventsourcingdb
 
 import "net/url"
 
-func (c *Client) getURL(path string) (*url.URL, error) {
+func (c *Client) getURL(baseURL *url.URL, path string) (*url.URL, error) {
 	urlPath, err := url.Parse(path)
 	if err != nil {
 		return nil, err
 	}
 
-	targetURL := c.baseURL.ResolveReference(urlPath)
+	targetURL := baseURL.ResolveReference(urlPath)
 
 	return targetURL, nil
 }
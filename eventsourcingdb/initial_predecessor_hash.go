@@ -0,0 +1,9 @@
+package eventsourcingdb
+
+import (
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// InitialPredecessorHash is the predecessor hash of the very first event in an
+// event store, since it has no predecessor.
+const InitialPredecessorHash = internal.InitialPredecessorHash
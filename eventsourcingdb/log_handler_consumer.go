@@ -0,0 +1,16 @@
+package eventsourcingdb
+
+import (
+	"strings"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// logHandlerConsumer hands the log lines of a container to a handler.
+type logHandlerConsumer struct {
+	handler func(line string)
+}
+
+func (c logHandlerConsumer) Accept(log testcontainers.Log) {
+	c.handler(strings.TrimRight(string(log.Content), "\n"))
+}
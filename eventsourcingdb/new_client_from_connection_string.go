@@ -0,0 +1,87 @@
+package eventsourcingdb
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+func NewClientFromConnectionString(connectionString string, options ...ClientOption) (*Client, error) {
+	baseURL, apiToken, connectionOptions, err := parseConnectionString(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Options given explicitly by the caller are applied last, so they take
+	// precedence over the ones derived from the connection string.
+	return NewClient(baseURL, apiToken, append(connectionOptions, options...)...)
+}
+
+func parseConnectionString(connectionString string) (*url.URL, string, []ClientOption, error) {
+	// The parse error is not wrapped, since it quotes the input, and the
+	// connection string contains the API token.
+	connectionURL, err := url.Parse(connectionString)
+	if err != nil {
+		return nil, "", nil, errors.New("invalid connection string, failed to parse it as URL")
+	}
+
+	if connectionURL.Scheme != "esdb" {
+		return nil, "", nil, fmt.Errorf("invalid connection string, scheme must be 'esdb', got '%s'", connectionURL.Scheme)
+	}
+
+	if connectionURL.Host == "" {
+		return nil, "", nil, errors.New("invalid connection string, host must not be empty")
+	}
+
+	if connectionURL.User == nil || connectionURL.User.Username() == "" {
+		return nil, "", nil, errors.New("invalid connection string, API token must be given as user info")
+	}
+
+	// A colon in the user info separates a password, which would otherwise be
+	// dropped silently. Tokens containing a colon must be percent-encoded.
+	if _, hasPassword := connectionURL.User.Password(); hasPassword {
+		return nil, "", nil, errors.New("invalid connection string, user info must only contain the API token, percent-encode colons within it")
+	}
+
+	if connectionURL.Path != "" && connectionURL.Path != "/" {
+		return nil, "", nil, fmt.Errorf("invalid connection string, path must be empty, got '%s'", connectionURL.Path)
+	}
+
+	scheme := "http"
+	var options []ClientOption
+
+	for key, values := range connectionURL.Query() {
+		if len(values) != 1 {
+			return nil, "", nil, fmt.Errorf("invalid connection string, parameter '%s' must be given exactly once", key)
+		}
+		value := values[0]
+
+		switch key {
+		case "tls":
+			useTLS, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, "", nil, fmt.Errorf("invalid connection string, parameter 'tls' must be a boolean, got '%s'", value)
+			}
+			if useTLS {
+				scheme = "https"
+			}
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, "", nil, fmt.Errorf("invalid connection string, parameter 'timeout' must be a duration, got '%s'", value)
+			}
+			options = append(options, WithTimeout(timeout))
+		default:
+			return nil, "", nil, fmt.Errorf("invalid connection string, unsupported parameter '%s'", key)
+		}
+	}
+
+	baseURL := &url.URL{
+		Scheme: scheme,
+		Host:   connectionURL.Host,
+	}
+
+	return baseURL, connectionURL.User.Username(), options, nil
+}
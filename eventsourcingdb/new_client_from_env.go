@@ -0,0 +1,62 @@
+package eventsourcingdb
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	connectionStringVariableName = "EVENTSOURCINGDB_CONNECTION_STRING"
+	urlVariableName              = "EVENTSOURCINGDB_URL"
+	apiTokenVariableName         = "EVENTSOURCINGDB_API_TOKEN"
+	apiTokenFileVariableName     = "EVENTSOURCINGDB_API_TOKEN_FILE"
+	timeoutVariableName          = "EVENTSOURCINGDB_TIMEOUT"
+)
+
+func NewClientFromEnv(options ...ClientOption) (*Client, error) {
+	connectionString := os.Getenv(connectionStringVariableName)
+	if connectionString != "" {
+		return NewClientFromConnectionString(connectionString, options...)
+	}
+
+	rawBaseURL := os.Getenv(urlVariableName)
+	if rawBaseURL == "" {
+		return nil, fmt.Errorf("either '%s' or '%s' must be set", connectionStringVariableName, urlVariableName)
+	}
+
+	baseURL, err := url.Parse(rawBaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for '%s': %w", urlVariableName, err)
+	}
+
+	err = validateBaseURL(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for '%s': %w", urlVariableName, err)
+	}
+
+	var environmentOptions []ClientOption
+
+	apiToken := os.Getenv(apiTokenVariableName)
+	apiTokenFile := os.Getenv(apiTokenFileVariableName)
+	switch {
+	case apiToken != "" && apiTokenFile != "":
+		return nil, fmt.Errorf("only one of '%s' and '%s' must be set", apiTokenVariableName, apiTokenFileVariableName)
+	case apiTokenFile != "":
+		environmentOptions = append(environmentOptions, WithTokenProvider(NewFileTokenProvider(apiTokenFile)))
+	case apiToken == "":
+		return nil, fmt.Errorf("either '%s' or '%s' must be set", apiTokenVariableName, apiTokenFileVariableName)
+	}
+
+	rawTimeout := os.Getenv(timeoutVariableName)
+	if rawTimeout != "" {
+		timeout, err := time.ParseDuration(rawTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for '%s': %w", timeoutVariableName, err)
+		}
+		environmentOptions = append(environmentOptions, WithTimeout(timeout))
+	}
+
+	return NewClient(baseURL, apiToken, append(environmentOptions, options...)...)
+}
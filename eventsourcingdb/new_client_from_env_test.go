@@ -0,0 +1,121 @@
+package eventsourcingdb_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestNewClientFromEnv(t *testing.T) {
+	unsetEnv := func(t *testing.T) {
+		t.Helper()
+
+		for _, name := range []string{
+			"EVENTSOURCINGDB_CONNECTION_STRING",
+			"EVENTSOURCINGDB_URL",
+			"EVENTSOURCINGDB_API_TOKEN",
+			"EVENTSOURCINGDB_API_TOKEN_FILE",
+			"EVENTSOURCINGDB_TIMEOUT",
+		} {
+			t.Setenv(name, "")
+		}
+	}
+
+	t.Run("uses the connection string if it is set", func(t *testing.T) {
+		unsetEnv(t)
+
+		server := newVerifyAPITokenServer(t, "secret")
+		server.Start()
+
+		host := strings.TrimPrefix(server.URL, "http://")
+		t.Setenv("EVENTSOURCINGDB_CONNECTION_STRING", fmt.Sprintf("esdb://secret@%s", host))
+
+		client, err := eventsourcingdb.NewClientFromEnv()
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+
+	t.Run("uses the URL and the API token", func(t *testing.T) {
+		unsetEnv(t)
+
+		server := newVerifyAPITokenServer(t, "secret")
+		server.Start()
+
+		t.Setenv("EVENTSOURCINGDB_URL", server.URL)
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN", "secret")
+		t.Setenv("EVENTSOURCINGDB_TIMEOUT", "5s")
+
+		client, err := eventsourcingdb.NewClientFromEnv()
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+
+	t.Run("reads the API token from a file", func(t *testing.T) {
+		unsetEnv(t)
+
+		server := newVerifyAPITokenServer(t, "secret")
+		server.Start()
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		err := os.WriteFile(tokenFile, []byte("secret\n"), 0600)
+		require.NoError(t, err)
+
+		t.Setenv("EVENTSOURCINGDB_URL", server.URL)
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN_FILE", tokenFile)
+
+		client, err := eventsourcingdb.NewClientFromEnv()
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns an error if no URL is set", func(t *testing.T) {
+		unsetEnv(t)
+
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN", "secret")
+
+		_, err := eventsourcingdb.NewClientFromEnv()
+		assert.ErrorContains(t, err, "EVENTSOURCINGDB_URL")
+	})
+
+	t.Run("returns an error if no API token is set", func(t *testing.T) {
+		unsetEnv(t)
+
+		t.Setenv("EVENTSOURCINGDB_URL", "http://localhost:3000")
+
+		_, err := eventsourcingdb.NewClientFromEnv()
+		assert.ErrorContains(t, err, "EVENTSOURCINGDB_API_TOKEN")
+	})
+
+	t.Run("returns an error for an invalid URL", func(t *testing.T) {
+		unsetEnv(t)
+
+		t.Setenv("EVENTSOURCINGDB_URL", "http://localhost:3000/api")
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN", "secret")
+
+		_, err := eventsourcingdb.NewClientFromEnv()
+		assert.ErrorContains(t, err, "EVENTSOURCINGDB_URL")
+	})
+
+	t.Run("returns an error for an invalid timeout", func(t *testing.T) {
+		unsetEnv(t)
+
+		t.Setenv("EVENTSOURCINGDB_URL", "http://localhost:3000")
+		t.Setenv("EVENTSOURCINGDB_API_TOKEN", "secret")
+		t.Setenv("EVENTSOURCINGDB_TIMEOUT", "soon")
+
+		_, err := eventsourcingdb.NewClientFromEnv()
+		assert.ErrorContains(t, err, "EVENTSOURCINGDB_TIMEOUT")
+	})
+}
@@ -0,0 +1,25 @@
+package eventsourcingdb
+
+import (
+	"time"
+)
+
+// NewCloudEventFromEvent converts an event to the CloudEvents format, e.g. to
+// serialize it with the same field names as the API uses.
+func NewCloudEventFromEvent(event Event) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     event.SpecVersion,
+		ID:              event.ID,
+		Time:            event.Time.Format(time.RFC3339Nano),
+		Source:          event.Source,
+		Subject:         event.Subject,
+		Type:            event.Type,
+		DataContentType: event.DataContentType,
+		Data:            event.Data,
+		Hash:            event.Hash,
+		PredecessorHash: event.PredecessorHash,
+		TraceParent:     event.TraceParent,
+		TraceState:      event.TraceState,
+		Signature:       event.Signature,
+	}
+}
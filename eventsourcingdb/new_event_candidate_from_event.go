@@ -0,0 +1,14 @@
+package eventsourcingdb
+
+// newEventCandidateFromEvent returns a candidate to write a copy of the given
+// event. The target assigns a new ID, time, and hash to the copy.
+func newEventCandidateFromEvent(event Event) EventCandidate {
+	return EventCandidate{
+		Source:      event.Source,
+		Subject:     event.Subject,
+		Type:        event.Type,
+		Data:        event.Data,
+		TraceParent: event.TraceParent,
+		TraceState:  event.TraceState,
+	}
+}
@@ -0,0 +1,31 @@
+package eventsourcingdb
+
+import (
+	"time"
+)
+
+// NewEventFromCloudEvent converts an event in the CloudEvents format, e.g. as
+// read from a backup file, to an event. It is the inverse of
+// NewCloudEventFromEvent.
+func NewEventFromCloudEvent(cloudEvent CloudEvent) (Event, error) {
+	cloudEventTime, err := time.Parse(time.RFC3339Nano, cloudEvent.Time)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return Event{
+		SpecVersion:     cloudEvent.SpecVersion,
+		ID:              cloudEvent.ID,
+		Time:            cloudEventTime,
+		Source:          cloudEvent.Source,
+		Subject:         cloudEvent.Subject,
+		Type:            cloudEvent.Type,
+		DataContentType: cloudEvent.DataContentType,
+		Data:            cloudEvent.Data,
+		Hash:            cloudEvent.Hash,
+		PredecessorHash: cloudEvent.PredecessorHash,
+		TraceParent:     cloudEvent.TraceParent,
+		TraceState:      cloudEvent.TraceState,
+		Signature:       cloudEvent.Signature,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package eventsourcingdb_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestNewEventFromCloudEvent(t *testing.T) {
+	t.Run("converts a cloud event back to the original event", func(t *testing.T) {
+		traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+		event := eventsourcingdb.Event{
+			SpecVersion:     "1.0",
+			ID:              "0",
+			Time:            time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC),
+			Source:          "https://www.eventsourcingdb.io",
+			Subject:         "/test",
+			Type:            "io.eventsourcingdb.test",
+			DataContentType: "application/json",
+			Data:            json.RawMessage(`{"value":23}`),
+			Hash:            "hash",
+			PredecessorHash: eventsourcingdb.InitialPredecessorHash,
+			TraceParent:     &traceParent,
+		}
+
+		actual, err := eventsourcingdb.NewEventFromCloudEvent(eventsourcingdb.NewCloudEventFromEvent(event))
+		require.NoError(t, err)
+		assert.Equal(t, event, actual)
+	})
+
+	t.Run("returns an error if the time is invalid", func(t *testing.T) {
+		_, err := eventsourcingdb.NewEventFromCloudEvent(eventsourcingdb.CloudEvent{
+			Time: "not a time",
+		})
+		assert.Error(t, err)
+	})
+}
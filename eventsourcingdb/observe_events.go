@@ -1,12 +1,10 @@
 package eventsourcingdb
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
 
@@ -19,12 +17,6 @@ func (c *Client) ObserveEvents(
 	options ObserveEventsOptions,
 ) iter.Seq2[Event, error] {
 	return func(yield func(Event, error) bool) {
-		observeEventsURL, err := c.getURL("/api/v1/observe-events")
-		if err != nil {
-			yield(Event{}, err)
-			return
-		}
-
 		type RequestBodyBound struct {
 			ID   string `json:"id"`
 			Type string `json:"type"`
@@ -73,31 +65,13 @@ func (c *Client) ObserveEvents(
 			return
 		}
 
-		requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-		request := &http.Request{
-			Method: http.MethodPost,
-			URL:    observeEventsURL,
-			Header: http.Header{
-				"Authorization": []string{"Bearer " + c.apiToken},
-				"Content-Type":  []string{"application/json"},
-			},
-			Body: requestBodyReader,
-		}
-
-		response, err := http.DefaultClient.Do(request)
+		response, err := c.sendRequest(ctx, http.MethodPost, "/api/v1/observe-events", requestBodyJSON)
 		if err != nil {
 			yield(Event{}, err)
 			return
 		}
 		defer response.Body.Close()
 
-		err = internal.ValidateServerHeader(response)
-		if err != nil {
-			yield(Event{}, err)
-			return
-		}
-
 		if response.StatusCode != http.StatusOK {
 			yield(Event{}, fmt.Errorf("failed to observe events, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 			return
@@ -112,6 +86,7 @@ func (c *Client) ObserveEvents(
 					// error.
 					return
 				}
+				c.reportStreamFailure(response)
 				yield(Event{}, err)
 				return
 			}
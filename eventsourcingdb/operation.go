@@ -0,0 +1,40 @@
+package eventsourcingdb
+
+import (
+	"strings"
+)
+
+type Operation string
+
+const (
+	OperationWriteEvents         Operation = "write-events"
+	OperationReadEvents          Operation = "read-events"
+	OperationObserveEvents       Operation = "observe-events"
+	OperationRunEventQLQuery     Operation = "run-eventql-query"
+	OperationReadSubjects        Operation = "read-subjects"
+	OperationReadEventTypes      Operation = "read-event-types"
+	OperationReadEventType       Operation = "read-event-type"
+	OperationRegisterEventSchema Operation = "register-event-schema"
+	OperationVerifyAPIToken      Operation = "verify-api-token"
+)
+
+func getOperation(path string) Operation {
+	return Operation(strings.TrimPrefix(path, "/api/v1/"))
+}
+
+func isSupportedOperation(operation Operation) bool {
+	switch operation {
+	case OperationWriteEvents,
+		OperationReadEvents,
+		OperationObserveEvents,
+		OperationRunEventQLQuery,
+		OperationReadSubjects,
+		OperationReadEventTypes,
+		OperationReadEventType,
+		OperationRegisterEventSchema,
+		OperationVerifyAPIToken:
+		return true
+	default:
+		return false
+	}
+}
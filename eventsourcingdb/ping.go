@@ -1,6 +1,7 @@
 package eventsourcingdb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,18 +10,47 @@ import (
 )
 
 func (c *Client) Ping() error {
-	pingURL, err := c.getURL("/api/v1/ping")
+	if c.getIsClosed() {
+		return ErrClientClosed
+	}
+
+	ctx := context.Background()
+
+	var err error
+	for _, endpoint := range c.selectEndpoints(ctx, "/api/v1/ping") {
+		err = c.ping(ctx, endpoint)
+		if err == nil {
+			return nil
+		}
+
+		if len(c.endpoints) > 1 {
+			endpoint.setIsHealthy(false)
+		}
+	}
+
+	return err
+}
+
+// ping checks that the endpoint is reachable, and records the version of the
+// server behind it.
+func (c *Client) ping(ctx context.Context, endpoint *endpoint) error {
+	pingURL, err := c.getURL(endpoint.baseURL, "/api/v1/ping")
 	if err != nil {
 		return err
 	}
 
-	response, err := http.Get(pingURL.String())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, pingURL.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.httpClient.Do(request)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	err = internal.ValidateServerHeader(response)
+	err = c.validatePingServerHeader(response)
 	if err != nil {
 		return err
 	}
@@ -43,5 +73,14 @@ func (c *Client) Ping() error {
 		return errors.New("failed to ping")
 	}
 
+	if _, ok := c.serverHeaderPolicy.(verifyOnceServerHeaderPolicy); ok {
+		c.isServerVerified.Store(true)
+	}
+
+	// The version is optional, since depending on the server header policy,
+	// the response may not identify the server at all.
+	serverVersion, _ := internal.GetServerVersion(response, c.getServerHeaderName())
+	c.recordServerInfo(endpoint, serverVersion)
+
 	return nil
 }
@@ -0,0 +1,107 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+func validateRateLimit(rateLimit RateLimit) error {
+	if rateLimit.RequestsPerSecond <= 0 {
+		return errors.New("requests per second must be greater than zero")
+	}
+	if rateLimit.Burst < 1 {
+		return errors.New("burst must be at least one")
+	}
+
+	return nil
+}
+
+type limits struct {
+	tokenBucket *internal.TokenBucket
+	semaphore   *internal.Semaphore
+}
+
+func (c *Client) getOperationLimits(operation Operation) *limits {
+	operationLimits, ok := c.operationLimits[operation]
+	if !ok {
+		operationLimits = &limits{}
+		c.operationLimits[operation] = operationLimits
+	}
+
+	return operationLimits
+}
+
+// acquireLimits waits until both the limits of the operation and the limits
+// of the client allow another request. The returned function releases the
+// concurrency slots, and must be called once the request is done, i.e. once
+// its response body has been closed.
+//
+// The concurrency slots are acquired before the rate limit tokens, and the
+// tokens taken so far are returned if waiting fails, so that a request that
+// is never sent does not use up the rate limit.
+func (c *Client) acquireLimits(ctx context.Context, path string) (func(), error) {
+	var releases []func()
+	release := func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+
+	var tokenBuckets []*internal.TokenBucket
+	for _, limits := range []*limits{c.operationLimits[getOperation(path)], c.limits} {
+		if limits == nil {
+			continue
+		}
+
+		if limits.semaphore != nil {
+			err := limits.semaphore.Acquire(ctx)
+			if err != nil {
+				release()
+				return nil, err
+			}
+			releases = append(releases, limits.semaphore.Release)
+		}
+
+		if limits.tokenBucket != nil {
+			tokenBuckets = append(tokenBuckets, limits.tokenBucket)
+		}
+	}
+
+	for i, tokenBucket := range tokenBuckets {
+		err := tokenBucket.Wait(ctx)
+		if err != nil {
+			for _, takenTokenBucket := range tokenBuckets[:i] {
+				takenTokenBucket.Return()
+			}
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// releasingBody releases the concurrency slots of a request once its body is
+// closed, so that streams such as ObserveEvents keep their slot as long as
+// they are open.
+type releasingBody struct {
+	io.ReadCloser
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.releaseOnce.Do(b.release)
+
+	return err
+}
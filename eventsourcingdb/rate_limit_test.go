@@ -0,0 +1,237 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+type rateLimitTestServer struct {
+	baseURL                *url.URL
+	inFlight               atomic.Int32
+	maxInFlight            atomic.Int32
+	observeEventsRequested chan struct{}
+}
+
+func newRateLimitTestServer(t *testing.T) *rateLimitTestServer {
+	t.Helper()
+
+	testServer := &rateLimitTestServer{
+		observeEventsRequested: make(chan struct{}, 10),
+	}
+
+	testServer.baseURL = newStubServer(t, stubServerOptions{
+		Routes: map[string]http.HandlerFunc{
+			"/api/v1/verify-api-token": func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(20 * time.Millisecond)
+				fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.api-token-verified"}`)
+			},
+			"/api/v1/write-events": func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `[]`)
+			},
+			"/api/v1/read-events": func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			},
+			"/api/v1/observe-events": func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				fmt.Fprint(w, `{"type":"heartbeat","payload":{}}`+"\n")
+				w.(http.Flusher).Flush()
+				testServer.observeEventsRequested <- struct{}{}
+				<-r.Context().Done()
+			},
+		},
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				inFlight := testServer.inFlight.Add(1)
+				defer testServer.inFlight.Add(-1)
+
+				for {
+					maxInFlight := testServer.maxInFlight.Load()
+					if inFlight <= maxInFlight || testServer.maxInFlight.CompareAndSwap(maxInFlight, inFlight) {
+						break
+					}
+				}
+
+				next.ServeHTTP(w, r)
+			})
+		},
+	})
+
+	return testServer
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("limits the rate of requests", func(t *testing.T) {
+		server := newRateLimitTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithRateLimit(eventsourcingdb.RateLimit{RequestsPerSecond: 20, Burst: 1}),
+		)
+		require.NoError(t, err)
+
+		start := time.Now()
+		for range 3 {
+			_, err := client.WriteEvents(nil, nil)
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("limits the number of concurrent requests", func(t *testing.T) {
+		server := newRateLimitTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithMaxConcurrentRequests(2),
+		)
+		require.NoError(t, err)
+
+		var waitGroup sync.WaitGroup
+		for range 6 {
+			waitGroup.Go(func() {
+				err := client.VerifyAPIToken()
+				assert.NoError(t, err)
+			})
+		}
+		waitGroup.Wait()
+
+		assert.Equal(t, int32(2), server.maxInFlight.Load())
+	})
+
+	t.Run("applies limits per operation", func(t *testing.T) {
+		server := newRateLimitTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithOperationRateLimit(
+				eventsourcingdb.OperationVerifyAPIToken,
+				eventsourcingdb.RateLimit{RequestsPerSecond: 0.1, Burst: 1},
+			),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+
+		// Other operations are not affected by the exhausted budget.
+		start := time.Now()
+		for range 3 {
+			_, err := client.WriteEvents(nil, nil)
+			require.NoError(t, err)
+		}
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("keeps the slot of a stream until it is closed", func(t *testing.T) {
+		server := newRateLimitTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithOperationMaxConcurrentRequests(eventsourcingdb.OperationObserveEvents, 1),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			}
+		}()
+		<-server.observeEventsRequested
+
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer waitCancel()
+
+		var streamErr error
+		for _, err := range client.ObserveEvents(waitCtx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			streamErr = err
+		}
+		assert.ErrorIs(t, streamErr, context.DeadlineExceeded)
+
+		cancel()
+		<-done
+
+		secondCtx, secondCancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, secondCancel)
+
+		for _, err := range client.ObserveEvents(secondCtx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("does not use up the rate limit of a request that is never sent", func(t *testing.T) {
+		server := newRateLimitTestServer(t)
+
+		client, err := eventsourcingdb.NewClient(
+			server.baseURL,
+			"secret",
+			eventsourcingdb.WithMaxConcurrentRequests(1),
+			eventsourcingdb.WithOperationRateLimit(
+				eventsourcingdb.OperationReadEvents,
+				eventsourcingdb.RateLimit{RequestsPerSecond: 0.1, Burst: 1},
+			),
+		)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			}
+		}()
+		<-server.observeEventsRequested
+
+		// The stream holds the only slot, so waiting for it times out.
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer waitCancel()
+
+		var readErr error
+		for _, err := range client.ReadEvents(waitCtx, "/", eventsourcingdb.ReadEventsOptions{Recursive: true}) {
+			readErr = err
+		}
+		assert.ErrorIs(t, readErr, context.DeadlineExceeded)
+
+		cancel()
+		<-done
+
+		// The token of the read that was never sent is still available.
+		readCtx, readCancel := context.WithTimeout(context.Background(), time.Second)
+		defer readCancel()
+
+		for _, err := range client.ReadEvents(readCtx, "/", eventsourcingdb.ReadEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("returns an error for invalid limits", func(t *testing.T) {
+		baseURL, err := url.Parse("http://localhost:3000")
+		require.NoError(t, err)
+
+		for _, option := range []eventsourcingdb.ClientOption{
+			eventsourcingdb.WithRateLimit(eventsourcingdb.RateLimit{RequestsPerSecond: 0, Burst: 1}),
+			eventsourcingdb.WithRateLimit(eventsourcingdb.RateLimit{RequestsPerSecond: 1, Burst: 0}),
+			eventsourcingdb.WithMaxConcurrentRequests(0),
+			eventsourcingdb.WithOperationMaxConcurrentRequests("non-existent", 1),
+		} {
+			_, err = eventsourcingdb.NewClient(baseURL, "secret", option)
+			assert.Error(t, err)
+		}
+	})
+}
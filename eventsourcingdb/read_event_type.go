@@ -1,10 +1,9 @@
 package eventsourcingdb
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
@@ -13,11 +12,6 @@ import (
 func (c *Client) ReadEventType(
 	eventType string,
 ) (EventType, error) {
-	readEventTypeURL, err := c.getURL("/api/v1/read-event-type")
-	if err != nil {
-		return EventType{}, err
-	}
-
 	type RequestBody struct {
 		EventType string `json:"eventType"`
 	}
@@ -30,29 +24,12 @@ func (c *Client) ReadEventType(
 		return EventType{}, err
 	}
 
-	requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-	request := &http.Request{
-		Method: http.MethodPost,
-		URL:    readEventTypeURL,
-		Header: http.Header{
-			"Authorization": []string{"Bearer " + c.apiToken},
-			"Content-Type":  []string{"application/json"},
-		},
-		Body: requestBodyReader,
-	}
-
-	response, err := http.DefaultClient.Do(request)
+	response, err := c.sendRequest(context.Background(), http.MethodPost, "/api/v1/read-event-type", requestBodyJSON)
 	if err != nil {
 		return EventType{}, err
 	}
 	defer response.Body.Close()
 
-	err = internal.ValidateServerHeader(response)
-	if err != nil {
-		return EventType{}, err
-	}
-
 	if response.StatusCode != http.StatusOK {
 		return EventType{}, fmt.Errorf("failed to read event type, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
 	}
@@ -1,12 +1,10 @@
 package eventsourcingdb
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
 
@@ -17,12 +15,6 @@ func (c *Client) ReadEventTypes(
 	ctx context.Context,
 ) iter.Seq2[EventType, error] {
 	return func(yield func(EventType, error) bool) {
-		readEventTypesURL, err := c.getURL("/api/v1/read-event-types")
-		if err != nil {
-			yield(EventType{}, err)
-			return
-		}
-
 		type RequestBody struct{}
 		requestBody := RequestBody{}
 
@@ -32,31 +24,13 @@ func (c *Client) ReadEventTypes(
 			return
 		}
 
-		requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-		request := &http.Request{
-			Method: http.MethodPost,
-			URL:    readEventTypesURL,
-			Header: http.Header{
-				"Authorization": []string{"Bearer " + c.apiToken},
-				"Content-Type":  []string{"application/json"},
-			},
-			Body: requestBodyReader,
-		}
-
-		response, err := http.DefaultClient.Do(request)
+		response, err := c.sendRequest(ctx, http.MethodPost, "/api/v1/read-event-types", requestBodyJSON)
 		if err != nil {
 			yield(EventType{}, err)
 			return
 		}
 		defer response.Body.Close()
 
-		err = internal.ValidateServerHeader(response)
-		if err != nil {
-			yield(EventType{}, err)
-			return
-		}
-
 		if response.StatusCode != http.StatusOK {
 			yield(EventType{}, fmt.Errorf("failed to read event types, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 			return
@@ -1,12 +1,10 @@
 package eventsourcingdb
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
 
@@ -19,12 +17,6 @@ func (c *Client) ReadEvents(
 	options ReadEventsOptions,
 ) iter.Seq2[Event, error] {
 	return func(yield func(Event, error) bool) {
-		readEventsURL, err := c.getURL("/api/v1/read-events")
-		if err != nil {
-			yield(Event{}, err)
-			return
-		}
-
 		type RequestBodyBound struct {
 			ID   string `json:"id"`
 			Type string `json:"type"`
@@ -85,31 +77,13 @@ func (c *Client) ReadEvents(
 			return
 		}
 
-		requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-		request := &http.Request{
-			Method: http.MethodPost,
-			URL:    readEventsURL,
-			Header: http.Header{
-				"Authorization": []string{"Bearer " + c.apiToken},
-				"Content-Type":  []string{"application/json"},
-			},
-			Body: requestBodyReader,
-		}
-
-		response, err := http.DefaultClient.Do(request)
+		response, err := c.sendRequest(ctx, http.MethodPost, "/api/v1/read-events", requestBodyJSON)
 		if err != nil {
 			yield(Event{}, err)
 			return
 		}
 		defer response.Body.Close()
 
-		err = internal.ValidateServerHeader(response)
-		if err != nil {
-			yield(Event{}, err)
-			return
-		}
-
 		if response.StatusCode != http.StatusOK {
 			yield(Event{}, fmt.Errorf("failed to read events, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 			return
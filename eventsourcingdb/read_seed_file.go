@@ -0,0 +1,36 @@
+package eventsourcingdb
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// readSeedFile reads event candidates from a file that contains either a JSON
+// array of event candidates, or one event candidate per line (NDJSON).
+func readSeedFile(path string) ([]EventCandidate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	eventCandidates, err := internal.UnmarshalEventCandidates(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed file '%s': %w", path, err)
+	}
+
+	events := make([]EventCandidate, 0, len(eventCandidates))
+	for _, event := range eventCandidates {
+		events = append(events, EventCandidate{
+			Source:      event.Source,
+			Subject:     event.Subject,
+			Type:        event.Type,
+			Data:        event.Data,
+			TraceParent: event.TraceParent,
+			TraceState:  event.TraceState,
+		})
+	}
+
+	return events, nil
+}
@@ -1,12 +1,10 @@
 package eventsourcingdb
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
 
@@ -18,12 +16,6 @@ func (c *Client) ReadSubjects(
 	baseSubject string,
 ) iter.Seq2[string, error] {
 	return func(yield func(string, error) bool) {
-		readSubjectsURL, err := c.getURL("/api/v1/read-subjects")
-		if err != nil {
-			yield("", err)
-			return
-		}
-
 		type RequestBody struct {
 			BaseSubject string `json:"baseSubject"`
 		}
@@ -38,31 +30,13 @@ func (c *Client) ReadSubjects(
 			return
 		}
 
-		requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-		request := &http.Request{
-			Method: http.MethodPost,
-			URL:    readSubjectsURL,
-			Header: http.Header{
-				"Authorization": []string{"Bearer " + c.apiToken},
-				"Content-Type":  []string{"application/json"},
-			},
-			Body: requestBodyReader,
-		}
-
-		response, err := http.DefaultClient.Do(request)
+		response, err := c.sendRequest(ctx, http.MethodPost, "/api/v1/read-subjects", requestBodyJSON)
 		if err != nil {
 			yield("", err)
 			return
 		}
 		defer response.Body.Close()
 
-		err = internal.ValidateServerHeader(response)
-		if err != nil {
-			yield("", err)
-			return
-		}
-
 		if response.StatusCode != http.StatusOK {
 			yield("", fmt.Errorf("failed to read subjects, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 			return
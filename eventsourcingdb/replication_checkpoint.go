@@ -0,0 +1,67 @@
+package eventsourcingdb
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ReplicationCheckpoint records how far a Replicator has copied events.
+type ReplicationCheckpoint struct {
+	LastSourceEventID string `json:"lastSourceEventId"`
+	LastTargetEventID string `json:"lastTargetEventId"`
+}
+
+func readReplicationCheckpoint(path string) (ReplicationCheckpoint, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ReplicationCheckpoint{}, nil
+	}
+	if err != nil {
+		return ReplicationCheckpoint{}, err
+	}
+
+	var checkpoint ReplicationCheckpoint
+	err = json.Unmarshal(content, &checkpoint)
+	if err != nil {
+		return ReplicationCheckpoint{}, err
+	}
+
+	return checkpoint, nil
+}
+
+// writeReplicationCheckpoint replaces the file atomically, so that a crash
+// while writing does not leave a corrupted checkpoint behind.
+func writeReplicationCheckpoint(path string, checkpoint ReplicationCheckpoint) error {
+	content, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(content)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
@@ -0,0 +1,90 @@
+package eventsourcingdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// replicationEventIDsFile appends the mapping from source to target event IDs
+// to an NDJSON file next to the checkpoint, so that the checkpoint itself does
+// not grow with the number of copied events.
+type replicationEventIDsFile struct {
+	file *os.File
+}
+
+type replicationEventID struct {
+	SourceEventID string `json:"sourceEventId"`
+	TargetEventID string `json:"targetEventId"`
+}
+
+// getReplicationEventIDsFilePath derives the path from the checkpoint file, e.g.
+// replication.event-ids.ndjson for replication.json.
+func getReplicationEventIDsFilePath(checkpointFilePath string) string {
+	return strings.TrimSuffix(checkpointFilePath, filepath.Ext(checkpointFilePath)) + ".event-ids.ndjson"
+}
+
+// openReplicationEventIDsFile opens the file for appending, and adds the event
+// IDs it contains to the given map. A partial last line, as left behind by a
+// crash while writing, is removed.
+func openReplicationEventIDsFile(path string, eventIDs map[string]string) (*replicationEventIDsFile, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = truncateTornTail(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	for line, err := range internal.UnmarshalNDJSON(context.Background(), file) {
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if line.Type != "eventId" {
+			file.Close()
+			return nil, fmt.Errorf("unsupported line type '%s'", line.Type)
+		}
+
+		var eventID replicationEventID
+		err = json.Unmarshal(line.Payload, &eventID)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+
+		eventIDs[eventID.SourceEventID] = eventID.TargetEventID
+	}
+
+	return &replicationEventIDsFile{file: file}, nil
+}
+
+func (f *replicationEventIDsFile) Append(eventIDs []replicationEventID) error {
+	var buffer bytes.Buffer
+	encoder := internal.NewJSONEncoder(&buffer)
+
+	for _, eventID := range eventIDs {
+		err := encoder.Encode(map[string]any{
+			"type":    "eventId",
+			"payload": eventID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return appendLines(f.file, buffer.Bytes())
+}
+
+func (f *replicationEventIDsFile) Close() error {
+	return f.file.Close()
+}
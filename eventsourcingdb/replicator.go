@@ -0,0 +1,335 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+var ErrReplicationTargetDiverged = errors.New("replication target has diverged from the checkpoint")
+
+// Replicator copies the events of a source event store to a target event
+// store in chronological order.
+type Replicator struct {
+	source             EventStore
+	target             EventStore
+	batchSize          int
+	checkpointFilePath string
+	eventIDHandler     func(sourceEventID string, targetEventID string) error
+	isLive             bool
+
+	mutex        sync.Mutex
+	checkpoint   ReplicationCheckpoint
+	eventIDs     map[string]string
+	eventIDsFile *replicationEventIDsFile
+	eventTypes   map[string]struct{}
+}
+
+func NewReplicator(source EventStore, target EventStore) *Replicator {
+	return &Replicator{
+		source:    source,
+		target:    target,
+		batchSize: 100,
+		eventIDs:  map[string]string{},
+	}
+}
+
+// WithBatchSize sets the number of events written to the target per request.
+// It defaults to 100.
+func (r *Replicator) WithBatchSize(batchSize int) *Replicator {
+	r.batchSize = batchSize
+	return r
+}
+
+// WithCheckpointFile persists the checkpoint to the given file after every
+// batch, and resumes from it when running again. The mapping from source to
+// target event IDs is appended to a separate file next to it, e.g.
+// replication.event-ids.ndjson for replication.json, so that the checkpoint
+// keeps a constant size.
+func (r *Replicator) WithCheckpointFile(path string) *Replicator {
+	r.checkpointFilePath = path
+	return r
+}
+
+// WithEventIDHandler calls the given handler for every copied event, with the
+// ID of the event in the source and the ID the target assigned to it, e.g. to
+// persist a mapping between them. The handler is called before the checkpoint
+// is saved, and an error stops the replication.
+func (r *Replicator) WithEventIDHandler(handler func(sourceEventID string, targetEventID string) error) *Replicator {
+	r.eventIDHandler = handler
+	return r
+}
+
+// WithLiveReplication keeps copying new events once all existing events have
+// been copied, until the context passed to Run is canceled.
+func (r *Replicator) WithLiveReplication() *Replicator {
+	r.isLive = true
+	return r
+}
+
+// Run copies the event schemas of the source that are missing in the target,
+// and then all events that have not been copied yet. Whenever an event of a
+// type that has not been seen before is copied, the event schemas are copied
+// again, so that schemas registered in the meantime reach the target first.
+func (r *Replicator) Run(ctx context.Context) error {
+	if r.batchSize < 1 {
+		return errors.New("batch size must be at least one")
+	}
+
+	if r.checkpointFilePath != "" {
+		checkpoint, err := readReplicationCheckpoint(r.checkpointFilePath)
+		if err != nil {
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+
+		r.mutex.Lock()
+		r.checkpoint = checkpoint
+		eventIDsFile, err := openReplicationEventIDsFile(getReplicationEventIDsFilePath(r.checkpointFilePath), r.eventIDs)
+		r.mutex.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to read event IDs: %w", err)
+		}
+
+		r.eventIDsFile = eventIDsFile
+		defer func() {
+			eventIDsFile.Close()
+			r.eventIDsFile = nil
+		}()
+	}
+
+	err := r.verifyTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.copyEventSchemas(ctx)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]Event, 0, r.batchSize)
+	for event, err := range r.source.ReadEvents(ctx, "/", ReadEventsOptions{
+		Recursive:  true,
+		LowerBound: r.getLowerBound(),
+	}) {
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, event)
+		if len(batch) == r.batchSize {
+			err := r.copyEvents(ctx, batch)
+			if err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		err := r.copyEvents(ctx, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !r.isLive {
+		return nil
+	}
+
+	// Observing ends without an error once the context is canceled.
+	for event, err := range r.source.ObserveEvents(ctx, "/", ObserveEventsOptions{
+		Recursive:  true,
+		LowerBound: r.getLowerBound(),
+	}) {
+		if err != nil {
+			return err
+		}
+
+		err := r.copyEvents(ctx, []Event{event})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCheckpoint returns the current checkpoint.
+func (r *Replicator) GetCheckpoint() ReplicationCheckpoint {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.checkpoint
+}
+
+// GetTargetEventID returns the ID the target assigned to the event with the
+// given ID in the source, if the event has been copied. With a checkpoint file,
+// this includes events copied by earlier runs.
+func (r *Replicator) GetTargetEventID(sourceEventID string) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	targetEventID, ok := r.eventIDs[sourceEventID]
+	return targetEventID, ok
+}
+
+func (r *Replicator) getLowerBound() *Bound {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.checkpoint.LastSourceEventID == "" {
+		return nil
+	}
+
+	return &Bound{
+		ID:   r.checkpoint.LastSourceEventID,
+		Type: BoundTypeExclusive,
+	}
+}
+
+// verifyTarget makes sure that nothing was written to the target since the
+// checkpoint was saved, since the checkpoint would not reflect it, e.g. if the
+// replicator crashed after writing a batch, but before saving the checkpoint.
+func (r *Replicator) verifyTarget(ctx context.Context) error {
+	checkpoint := r.GetCheckpoint()
+	if checkpoint.LastSourceEventID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lastTargetEventID := ""
+	for event, err := range r.target.ReadEvents(ctx, "/", ReadEventsOptions{
+		Recursive: true,
+		Order:     OrderAntichronological(),
+	}) {
+		if err != nil {
+			return err
+		}
+
+		lastTargetEventID = event.ID
+		break
+	}
+
+	if lastTargetEventID != checkpoint.LastTargetEventID {
+		return fmt.Errorf("%w: expected last event '%s', got '%s'", ErrReplicationTargetDiverged, checkpoint.LastTargetEventID, lastTargetEventID)
+	}
+
+	return nil
+}
+
+func (r *Replicator) copyEventSchemas(ctx context.Context) error {
+	targetSchemas := map[string]map[string]any{}
+	for eventType, err := range r.target.ReadEventTypes(ctx) {
+		if err != nil {
+			return err
+		}
+		if eventType.Schema != nil {
+			targetSchemas[eventType.EventType] = *eventType.Schema
+		}
+	}
+
+	r.eventTypes = map[string]struct{}{}
+	sourceSchemas := map[string]map[string]any{}
+	for eventType, err := range r.source.ReadEventTypes(ctx) {
+		if err != nil {
+			return err
+		}
+
+		r.eventTypes[eventType.EventType] = struct{}{}
+		if eventType.Schema != nil {
+			sourceSchemas[eventType.EventType] = *eventType.Schema
+		}
+	}
+
+	for _, eventType := range slices.Sorted(maps.Keys(sourceSchemas)) {
+		targetSchema, ok := targetSchemas[eventType]
+		if ok {
+			if !reflect.DeepEqual(targetSchema, sourceSchemas[eventType]) {
+				return fmt.Errorf("failed to copy event schema for '%s': target has a different schema", eventType)
+			}
+			continue
+		}
+
+		err := r.target.RegisterEventSchema(eventType, sourceSchemas[eventType])
+		if err != nil {
+			return fmt.Errorf("failed to copy event schema for '%s': %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Replicator) copyEvents(ctx context.Context, events []Event) error {
+	eventCandidates := make([]EventCandidate, 0, len(events))
+	hasNewEventType := false
+	for _, event := range events {
+		eventCandidates = append(eventCandidates, newEventCandidateFromEvent(event))
+
+		_, ok := r.eventTypes[event.Type]
+		hasNewEventType = hasNewEventType || !ok
+	}
+
+	if hasNewEventType {
+		err := r.copyEventSchemas(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	writtenEvents, err := r.target.WriteEvents(eventCandidates, nil)
+	if err != nil {
+		return fmt.Errorf("failed to copy events: %w", err)
+	}
+
+	eventIDs := make([]replicationEventID, 0, len(writtenEvents))
+	r.mutex.Lock()
+	for i, writtenEvent := range writtenEvents {
+		eventIDs = append(eventIDs, replicationEventID{
+			SourceEventID: events[i].ID,
+			TargetEventID: writtenEvent.ID,
+		})
+		r.eventIDs[events[i].ID] = writtenEvent.ID
+	}
+	r.mutex.Unlock()
+
+	if r.eventIDsFile != nil {
+		err := r.eventIDsFile.Append(eventIDs)
+		if err != nil {
+			return fmt.Errorf("failed to write event IDs: %w", err)
+		}
+	}
+
+	if r.eventIDHandler != nil {
+		for _, eventID := range eventIDs {
+			err := r.eventIDHandler(eventID.SourceEventID, eventID.TargetEventID)
+			if err != nil {
+				return fmt.Errorf("failed to handle event IDs: %w", err)
+			}
+		}
+	}
+
+	r.mutex.Lock()
+	r.checkpoint = ReplicationCheckpoint{
+		LastSourceEventID: events[len(events)-1].ID,
+		LastTargetEventID: writtenEvents[len(writtenEvents)-1].ID,
+	}
+	r.mutex.Unlock()
+
+	if r.checkpointFilePath == "" {
+		return nil
+	}
+
+	err = writeReplicationCheckpoint(r.checkpointFilePath, r.GetCheckpoint())
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestReplicator(t *testing.T) {
+	type EventData struct {
+		Value int `json:"value"`
+	}
+
+	newEventCandidate := func(subject string, value int) eventsourcingdb.EventCandidate {
+		return eventsourcingdb.EventCandidate{
+			Source:  "https://www.eventsourcingdb.io",
+			Subject: subject,
+			Type:    "io.eventsourcingdb.test",
+			Data: EventData{
+				Value: value,
+			},
+		}
+	}
+
+	openStore := func(t *testing.T) *eventsourcingdb.EmbeddedStore {
+		t.Helper()
+
+		store, err := eventsourcingdb.OpenEmbeddedStore(filepath.Join(t.TempDir(), "events.ndjson"))
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Close() })
+
+		return store
+	}
+
+	readEvents := func(t *testing.T, eventStore eventsourcingdb.Reader) []eventsourcingdb.Event {
+		t.Helper()
+
+		events := []eventsourcingdb.Event{}
+		for event, err := range eventStore.ReadEvents(
+			context.Background(),
+			"/",
+			eventsourcingdb.ReadEventsOptions{Recursive: true},
+		) {
+			require.NoError(t, err)
+			events = append(events, event)
+		}
+
+		return events
+	}
+
+	writeEvents := func(t *testing.T, eventStore eventsourcingdb.Writer, values ...int) {
+		t.Helper()
+
+		for _, value := range values {
+			_, err := eventStore.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEventCandidate("/test", value),
+			}, nil)
+			require.NoError(t, err)
+		}
+	}
+
+	getValues := func(t *testing.T, events []eventsourcingdb.Event) []string {
+		t.Helper()
+
+		values := []string{}
+		for _, event := range events {
+			values = append(values, string(event.Data))
+		}
+
+		return values
+	}
+
+	t.Run("copies events and event schemas, and reports event IDs", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		writeEvents(t, source, 1, 2, 3)
+		writeEvents(t, target, 0)
+
+		err := source.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{
+			"type": "object",
+		})
+		require.NoError(t, err)
+
+		eventIDs := map[string]string{}
+		replicator := eventsourcingdb.NewReplicator(source, target).
+			WithBatchSize(2).
+			WithEventIDHandler(func(sourceEventID string, targetEventID string) error {
+				eventIDs[sourceEventID] = targetEventID
+				return nil
+			})
+		err = replicator.Run(context.Background())
+		require.NoError(t, err)
+
+		targetEvents := readEvents(t, target)
+		assert.Equal(t, []string{`{"value":0}`, `{"value":1}`, `{"value":2}`, `{"value":3}`}, getValues(t, targetEvents))
+		assert.Equal(t, map[string]string{"0": "1", "1": "2", "2": "3"}, eventIDs)
+
+		targetEventID, ok := replicator.GetTargetEventID("1")
+		assert.True(t, ok)
+		assert.Equal(t, "2", targetEventID)
+
+		_, ok = replicator.GetTargetEventID("3")
+		assert.False(t, ok)
+
+		checkpoint := replicator.GetCheckpoint()
+		assert.Equal(t, "2", checkpoint.LastSourceEventID)
+		assert.Equal(t, "3", checkpoint.LastTargetEventID)
+
+		eventType, err := target.ReadEventType("io.eventsourcingdb.test")
+		require.NoError(t, err)
+		require.NotNil(t, eventType.Schema)
+		assert.Equal(t, map[string]any{"type": "object"}, *eventType.Schema)
+	})
+
+	t.Run("resumes from a checkpoint file", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		checkpointFilePath := filepath.Join(t.TempDir(), "checkpoint.json")
+		writeEvents(t, source, 1, 2)
+
+		err := eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath).
+			Run(context.Background())
+		require.NoError(t, err)
+
+		writeEvents(t, source, 3)
+
+		sourceEventIDs := []string{}
+		replicator := eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath).
+			WithEventIDHandler(func(sourceEventID string, targetEventID string) error {
+				sourceEventIDs = append(sourceEventIDs, sourceEventID)
+				return nil
+			})
+		err = replicator.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{`{"value":1}`, `{"value":2}`, `{"value":3}`}, getValues(t, readEvents(t, target)))
+		assert.Equal(t, []string{"2"}, sourceEventIDs)
+
+		checkpoint := replicator.GetCheckpoint()
+		assert.Equal(t, "2", checkpoint.LastSourceEventID)
+		assert.Equal(t, "2", checkpoint.LastTargetEventID)
+	})
+
+	t.Run("persists event IDs next to the checkpoint file", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		directory := t.TempDir()
+		checkpointFilePath := filepath.Join(directory, "checkpoint.json")
+		writeEvents(t, source, 1, 2)
+		writeEvents(t, target, 0)
+
+		err := eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath).
+			Run(context.Background())
+		require.NoError(t, err)
+
+		checkpointFileInfo, err := os.Stat(checkpointFilePath)
+		require.NoError(t, err)
+
+		// Simulate a crash while appending to the event ID file.
+		eventIDsFile, err := os.OpenFile(filepath.Join(directory, "checkpoint.event-ids.ndjson"), os.O_WRONLY|os.O_APPEND, 0)
+		require.NoError(t, err)
+		_, err = eventIDsFile.WriteString(`{"type":"eventId","payload":{"sourceEve`)
+		require.NoError(t, err)
+		require.NoError(t, eventIDsFile.Close())
+
+		writeEvents(t, source, 3, 4)
+
+		replicator := eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath)
+		err = replicator.Run(context.Background())
+		require.NoError(t, err)
+
+		for sourceEventID, expectedTargetEventID := range map[string]string{"0": "1", "1": "2", "2": "3", "3": "4"} {
+			targetEventID, ok := replicator.GetTargetEventID(sourceEventID)
+			assert.True(t, ok)
+			assert.Equal(t, expectedTargetEventID, targetEventID)
+		}
+
+		resumedCheckpointFileInfo, err := os.Stat(checkpointFilePath)
+		require.NoError(t, err)
+		assert.Equal(t, checkpointFileInfo.Size(), resumedCheckpointFileInfo.Size())
+	})
+
+	t.Run("stops when the event ID handler fails", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		writeEvents(t, source, 1)
+
+		replicator := eventsourcingdb.NewReplicator(source, target).
+			WithEventIDHandler(func(sourceEventID string, targetEventID string) error {
+				return assert.AnError
+			})
+		err := replicator.Run(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, replicator.GetCheckpoint().LastSourceEventID)
+	})
+
+	t.Run("rejects a target that has diverged from the checkpoint", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		checkpointFilePath := filepath.Join(t.TempDir(), "checkpoint.json")
+		writeEvents(t, source, 1)
+
+		err := eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath).
+			Run(context.Background())
+		require.NoError(t, err)
+
+		writeEvents(t, target, 2)
+
+		err = eventsourcingdb.NewReplicator(source, target).
+			WithCheckpointFile(checkpointFilePath).
+			Run(context.Background())
+		assert.ErrorIs(t, err, eventsourcingdb.ErrReplicationTargetDiverged)
+	})
+
+	t.Run("rejects a conflicting event schema in the target", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+
+		err := source.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{"type": "object"})
+		require.NoError(t, err)
+		err = target.RegisterEventSchema("io.eventsourcingdb.test", map[string]any{"type": "array"})
+		require.NoError(t, err)
+
+		err = eventsourcingdb.NewReplicator(source, target).Run(context.Background())
+		assert.ErrorContains(t, err, "target has a different schema")
+	})
+
+	t.Run("continues copying new events in live mode", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		writeEvents(t, source, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		replicator := eventsourcingdb.NewReplicator(source, target).WithLiveReplication()
+		result := make(chan error, 1)
+		go func() {
+			result <- replicator.Run(ctx)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return replicator.GetCheckpoint().LastSourceEventID == "0"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		writeEvents(t, source, 2)
+
+		assert.Eventually(t, func() bool {
+			return replicator.GetCheckpoint().LastSourceEventID == "1"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-result)
+
+		assert.Equal(t, []string{`{"value":1}`, `{"value":2}`}, getValues(t, readEvents(t, target)))
+	})
+
+	t.Run("copies event schemas of new event types in live mode", func(t *testing.T) {
+		source := openStore(t)
+		target := openStore(t)
+		writeEvents(t, source, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mutex sync.Mutex
+		sourceEventIDs := []string{}
+		replicator := eventsourcingdb.NewReplicator(source, target).
+			WithLiveReplication().
+			WithEventIDHandler(func(sourceEventID string, targetEventID string) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				sourceEventIDs = append(sourceEventIDs, sourceEventID)
+				return nil
+			})
+		result := make(chan error, 1)
+		go func() {
+			result <- replicator.Run(ctx)
+		}()
+
+		assert.Eventually(t, func() bool {
+			return replicator.GetCheckpoint().LastSourceEventID == "0"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		err := source.RegisterEventSchema("io.eventsourcingdb.other", map[string]any{"type": "object"})
+		require.NoError(t, err)
+		_, err = source.WriteEvents([]eventsourcingdb.EventCandidate{
+			{
+				Source:  "https://www.eventsourcingdb.io",
+				Subject: "/test",
+				Type:    "io.eventsourcingdb.other",
+				Data:    EventData{Value: 2},
+			},
+		}, nil)
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return replicator.GetCheckpoint().LastSourceEventID == "1"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-result)
+
+		mutex.Lock()
+		assert.Equal(t, []string{"0", "1"}, sourceEventIDs)
+		mutex.Unlock()
+
+		eventType, err := target.ReadEventType("io.eventsourcingdb.other")
+		require.NoError(t, err)
+		require.NotNil(t, eventType.Schema)
+		assert.Equal(t, map[string]any{"type": "object"}, *eventType.Schema)
+	})
+}
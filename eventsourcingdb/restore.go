@@ -0,0 +1,199 @@
+package eventsourcingdb
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+var (
+	ErrInvalidBackup         = errors.New("invalid backup")
+	ErrRestoreTargetNotEmpty = errors.New("restore target is not empty")
+)
+
+const defaultRestoreBatchSize = 100
+
+type RestoreOptions struct {
+	// BatchSize is the number of events written per request. It defaults to
+	// 100.
+	BatchSize int
+}
+
+// Restore writes the events of a backup created by Backup to the given event
+// store, which must not contain any events or event types yet, and registers
+// the event schemas of the backup afterwards. While reading the backup, the
+// hash of every event and the chain of predecessor hashes, starting at the
+// initial predecessor hash, are verified.
+//
+// The restored events keep their IDs, but get new timestamps and therefore new
+// hashes. Since events are written in batches, an invalid backup may leave the
+// event store partially restored.
+func Restore(ctx context.Context, eventStore EventStore, reader io.Reader, options RestoreOptions) (BackupManifest, error) {
+	batchSize := options.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultRestoreBatchSize
+	}
+	if batchSize < 0 {
+		return BackupManifest{}, errors.New("batch size must be at least one")
+	}
+
+	err := ensureEventStoreIsEmpty(ctx, eventStore)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return BackupManifest{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+	defer gzipReader.Close()
+
+	var manifest *BackupManifest
+	var previousEvent *Event
+	eventCount := 0
+	firstEventID := ""
+	batch := make([]Event, 0, batchSize)
+
+	for line, err := range internal.UnmarshalNDJSON(ctx, gzipReader) {
+		if err != nil {
+			return BackupManifest{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+		}
+		if manifest != nil {
+			return BackupManifest{}, fmt.Errorf("%w: unexpected line after the manifest", ErrInvalidBackup)
+		}
+
+		switch line.Type {
+		case "event":
+			event, err := unmarshalBackupEvent(line.Payload)
+			if err != nil {
+				return BackupManifest{}, err
+			}
+
+			switch {
+			case previousEvent != nil && event.PredecessorHash != previousEvent.Hash:
+				return BackupManifest{}, fmt.Errorf("%w: predecessor hash of event '%s' does not match the hash of event '%s'", ErrInvalidBackup, event.ID, previousEvent.ID)
+			case previousEvent == nil && event.PredecessorHash != InitialPredecessorHash:
+				return BackupManifest{}, fmt.Errorf("%w: predecessor hash of event '%s' is not the initial hash, so the backup does not start with the first event", ErrInvalidBackup, event.ID)
+			}
+			if eventCount == 0 {
+				firstEventID = event.ID
+			}
+			eventCount++
+			previousEvent = &event
+
+			batch = append(batch, event)
+			if len(batch) == batchSize {
+				err := restoreEvents(eventStore, batch)
+				if err != nil {
+					return BackupManifest{}, err
+				}
+				batch = batch[:0]
+			}
+		case "manifest":
+			manifest = &BackupManifest{}
+			err := json.Unmarshal(line.Payload, manifest)
+			if err != nil {
+				return BackupManifest{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+			}
+		default:
+			return BackupManifest{}, fmt.Errorf("%w: unsupported line type '%s'", ErrInvalidBackup, line.Type)
+		}
+	}
+
+	if manifest == nil {
+		return BackupManifest{}, fmt.Errorf("%w: manifest is missing", ErrInvalidBackup)
+	}
+
+	lastEventID, finalHash := "", ""
+	if previousEvent != nil {
+		lastEventID, finalHash = previousEvent.ID, previousEvent.Hash
+	}
+	if manifest.EventCount != eventCount ||
+		manifest.FirstEventID != firstEventID ||
+		manifest.LastEventID != lastEventID ||
+		manifest.FinalHash != finalHash {
+		return BackupManifest{}, fmt.Errorf("%w: manifest does not match the events", ErrInvalidBackup)
+	}
+
+	if len(batch) > 0 {
+		err := restoreEvents(eventStore, batch)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+	}
+
+	for _, eventSchema := range manifest.EventSchemas {
+		err := eventStore.RegisterEventSchema(eventSchema.EventType, eventSchema.Schema)
+		if err != nil {
+			return BackupManifest{}, fmt.Errorf("failed to register event schema for '%s': %w", eventSchema.EventType, err)
+		}
+	}
+
+	return *manifest, nil
+}
+
+func ensureEventStoreIsEmpty(ctx context.Context, eventStore EventStore) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, err := range eventStore.ReadEvents(ctx, "/", ReadEventsOptions{Recursive: true}) {
+		if err != nil {
+			return err
+		}
+		return ErrRestoreTargetNotEmpty
+	}
+
+	for _, err := range eventStore.ReadEventTypes(ctx) {
+		if err != nil {
+			return err
+		}
+		return ErrRestoreTargetNotEmpty
+	}
+
+	return nil
+}
+
+func unmarshalBackupEvent(payload json.RawMessage) (Event, error) {
+	var cloudEvent internal.CloudEvent
+	err := json.Unmarshal(payload, &cloudEvent)
+	if err != nil {
+		return Event{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	event, err := NewEventFromCloudEvent(cloudEvent)
+	if err != nil {
+		return Event{}, fmt.Errorf("%w: %w", ErrInvalidBackup, err)
+	}
+
+	err = event.VerifyHash()
+	if err != nil {
+		return Event{}, fmt.Errorf("%w: event '%s': %w", ErrInvalidBackup, event.ID, err)
+	}
+
+	return event, nil
+}
+
+func restoreEvents(eventStore EventStore, events []Event) error {
+	eventCandidates := make([]EventCandidate, 0, len(events))
+	for _, event := range events {
+		eventCandidates = append(eventCandidates, newEventCandidateFromEvent(event))
+	}
+
+	writtenEvents, err := eventStore.WriteEvents(eventCandidates, nil)
+	if err != nil {
+		return fmt.Errorf("failed to restore events: %w", err)
+	}
+
+	for i, writtenEvent := range writtenEvents {
+		if writtenEvent.ID != events[i].ID {
+			return fmt.Errorf("failed to restore events: event '%s' was restored with ID '%s'", events[i].ID, writtenEvent.ID)
+		}
+	}
+
+	return nil
+}
@@ -1,11 +1,9 @@
 package eventsourcingdb
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"iter"
 	"net/http"
 
@@ -17,12 +15,6 @@ func (c *Client) RunEventQLQuery(
 	query string,
 ) iter.Seq2[json.RawMessage, error] {
 	return func(yield func(json.RawMessage, error) bool) {
-		runEventQLQueryURL, err := c.getURL("/api/v1/run-eventql-query")
-		if err != nil {
-			yield(nil, err)
-			return
-		}
-
 		type RequestBody struct {
 			Query string `json:"query"`
 		}
@@ -37,31 +29,13 @@ func (c *Client) RunEventQLQuery(
 			return
 		}
 
-		requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-		request := &http.Request{
-			Method: http.MethodPost,
-			URL:    runEventQLQueryURL,
-			Header: http.Header{
-				"Authorization": []string{"Bearer " + c.apiToken},
-				"Content-Type":  []string{"application/json"},
-			},
-			Body: requestBodyReader,
-		}
-
-		response, err := http.DefaultClient.Do(request)
+		response, err := c.sendRequest(ctx, http.MethodPost, "/api/v1/run-eventql-query", requestBodyJSON)
 		if err != nil {
 			yield(nil, err)
 			return
 		}
 		defer response.Body.Close()
 
-		err = internal.ValidateServerHeader(response)
-		if err != nil {
-			yield(nil, err)
-			return
-		}
-
 		if response.StatusCode != http.StatusOK {
 			yield(nil, fmt.Errorf("failed to run EventQL query, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK))
 			return
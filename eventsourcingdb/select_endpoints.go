@@ -0,0 +1,140 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) selectEndpoints(ctx context.Context, path string) []*endpoint {
+	if len(c.endpoints) == 1 {
+		return c.endpoints
+	}
+
+	start := 0
+	if c.endpointSelection == EndpointSelectionRoundRobin && !isWritePath(path) {
+		start = int((c.roundRobinCounter.Add(1) - 1) % uint64(len(c.endpoints)))
+	}
+
+	return c.orderEndpoints(ctx, path, start)
+}
+
+// peekEndpoints returns the endpoints in the order that the next read request
+// would use, without advancing the round robin counter, so that looking at
+// the endpoints does not shift the distribution of requests.
+func (c *Client) peekEndpoints(ctx context.Context, path string) []*endpoint {
+	if len(c.endpoints) == 1 {
+		return c.endpoints
+	}
+
+	start := 0
+	if c.endpointSelection == EndpointSelectionRoundRobin {
+		start = int(c.roundRobinCounter.Load() % uint64(len(c.endpoints)))
+	}
+
+	return c.orderEndpoints(ctx, path, start)
+}
+
+func (c *Client) orderEndpoints(ctx context.Context, path string, start int) []*endpoint {
+	candidates := make([]*endpoint, 0, len(c.endpoints))
+	for i := range c.endpoints {
+		candidates = append(candidates, c.endpoints[(start+i)%len(c.endpoints)])
+	}
+
+	// Observing sticks to the endpoint of the previous stream as long as it
+	// is healthy, so that reconnecting streams keep reading from the same
+	// server and only move on once that server has failed.
+	if path == "/api/v1/observe-events" {
+		if stickyEndpoint := c.observeEndpoint.Load(); stickyEndpoint != nil {
+			reordered := []*endpoint{stickyEndpoint}
+			for _, candidate := range candidates {
+				if candidate != stickyEndpoint {
+					reordered = append(reordered, candidate)
+				}
+			}
+			candidates = reordered
+		}
+	}
+
+	healthyEndpoints := make([]*endpoint, 0, len(candidates))
+	var unhealthyEndpoints []*endpoint
+
+	for _, candidate := range candidates {
+		if candidate.claimHealthCheck(c.healthCheckInterval) {
+			err := c.ping(ctx, candidate)
+			candidate.setIsHealthy(err == nil)
+		}
+
+		if candidate.getIsHealthy() {
+			healthyEndpoints = append(healthyEndpoints, candidate)
+			continue
+		}
+		unhealthyEndpoints = append(unhealthyEndpoints, candidate)
+	}
+
+	// Unhealthy endpoints are kept as a last resort, since failing for sure
+	// is worse than trying an endpoint that may have recovered in between.
+	return append(healthyEndpoints, unhealthyEndpoints...)
+}
+
+func (c *Client) shouldFailOver(path string, response *http.Response, err error) bool {
+	if len(c.endpoints) == 1 {
+		return false
+	}
+
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		// A write may have reached the server even though the response got
+		// lost, so repeating it on another endpoint could write the events
+		// twice. Only failing to connect at all proves that it did not.
+		if isWritePath(path) {
+			var opError *net.OpError
+			return errors.As(err, &opError) && opError.Op == "dial"
+		}
+
+		return true
+	}
+
+	if isWritePath(path) {
+		return false
+	}
+
+	switch response.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Client) reportStreamFailure(response *http.Response) {
+	if len(c.endpoints) == 1 {
+		return
+	}
+
+	for _, candidate := range c.endpoints {
+		if isSameEndpoint(candidate.baseURL, response.Request.URL) {
+			candidate.setIsHealthy(false)
+			c.observeEndpoint.CompareAndSwap(candidate, nil)
+			return
+		}
+	}
+}
+
+func isSameEndpoint(baseURL *url.URL, targetURL *url.URL) bool {
+	return baseURL.Scheme == targetURL.Scheme && baseURL.Host == targetURL.Host
+}
+
+func isWritePath(path string) bool {
+	switch path {
+	case "/api/v1/write-events", "/api/v1/register-event-schema":
+		return true
+	default:
+		return false
+	}
+}
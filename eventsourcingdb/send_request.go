@@ -0,0 +1,192 @@
+package eventsourcingdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func (c *Client) sendRequest(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	ctx, done, err := c.trackRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendTrackedRequest(ctx, method, path, body)
+	if err != nil {
+		done()
+
+		if c.getIsClosed() && errors.Is(err, context.Canceled) {
+			return nil, ErrClientClosed
+		}
+
+		return nil, err
+	}
+
+	response.Body = &releasingBody{
+		ReadCloser: response.Body,
+		release:    done,
+	}
+
+	return response, nil
+}
+
+func (c *Client) sendTrackedRequest(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	err := c.checkCircuitBreaker(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.verifyServerOnce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The body is compressed once up front, so that retries and failovers
+	// can reuse it.
+	if c.requestCompression && body != nil {
+		body, err = compressRequestBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
+		}
+	}
+
+	release, err := c.acquireLimits(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.sendRequestWithFailover(ctx, method, path, body)
+	c.recordCircuitBreakerResult(response, err)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	response.Body = &releasingBody{
+		ReadCloser: response.Body,
+		release:    release,
+	}
+
+	return response, nil
+}
+
+func (c *Client) sendRequestWithFailover(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	endpoints := c.selectEndpoints(ctx, path)
+
+	for i, endpoint := range endpoints {
+		err := c.checkServerVersion(ctx, endpoint)
+		if errors.Is(err, ErrIncompatibleServerVersion) {
+			return nil, err
+		}
+
+		var response *http.Response
+		if err == nil {
+			response, err = c.sendRequestToEndpoint(ctx, endpoint, method, path, body)
+		}
+
+		isLastEndpoint := i == len(endpoints)-1
+		if isLastEndpoint || !c.shouldFailOver(path, response, err) {
+			if err != nil {
+				return nil, err
+			}
+
+			err = c.validateServerHeader(response)
+			if err != nil {
+				response.Body.Close()
+				return nil, err
+			}
+
+			c.recordServerVersion(endpoint, response)
+			decompressResponse(response)
+			if path == "/api/v1/observe-events" {
+				c.observeEndpoint.Store(endpoint)
+			}
+
+			return response, nil
+		}
+
+		endpoint.setIsHealthy(false)
+		if response != nil {
+			response.Body.Close()
+		}
+	}
+
+	// This is unreachable, since the loop always returns on the last
+	// endpoint, and there is always at least one endpoint.
+	return nil, fmt.Errorf("failed to send request to '%s', no endpoint available", path)
+}
+
+func (c *Client) sendRequestToEndpoint(
+	ctx context.Context,
+	endpoint *endpoint,
+	method string,
+	path string,
+	body []byte,
+) (*http.Response, error) {
+	targetURL, err := c.getURL(endpoint.baseURL, path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := c.tokenProvider.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+
+	response, err := c.sendAuthenticatedRequest(ctx, method, targetURL, body, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusUnauthorized {
+		return response, nil
+	}
+
+	// The token may have been rotated since it was last read, so we refresh
+	// it and retry exactly once. If the refreshed token is unchanged, there
+	// is no point in retrying, and the original response is handed back to
+	// the caller, which reports it like any other unexpected status code.
+	refreshedToken, err := c.tokenProvider.RefreshToken(ctx)
+	if err != nil || refreshedToken == token {
+		return response, nil
+	}
+	response.Body.Close()
+
+	return c.sendAuthenticatedRequest(ctx, method, targetURL, body, refreshedToken)
+}
+
+func (c *Client) sendAuthenticatedRequest(
+	ctx context.Context,
+	method string,
+	targetURL *url.URL,
+	body []byte,
+	token string,
+) (*http.Response, error) {
+	var requestBody io.Reader
+	if body != nil {
+		requestBody = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, targetURL.String(), requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+		if c.requestCompression {
+			request.Header.Set("Content-Encoding", "gzip")
+		}
+	}
+	if c.responseCompression && isNDJSONPath(targetURL.Path) {
+		request.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	return c.httpClient.Do(request)
+}
@@ -0,0 +1,45 @@
+package eventsourcingdb
+
+type ServerHeaderPolicy interface {
+	serverHeaderPolicy()
+}
+
+type strictServerHeaderPolicy struct{}
+
+func (strictServerHeaderPolicy) serverHeaderPolicy() {}
+
+func NewStrictServerHeaderPolicy() ServerHeaderPolicy {
+	return strictServerHeaderPolicy{}
+}
+
+type customServerHeaderPolicy struct {
+	headerName string
+}
+
+func (customServerHeaderPolicy) serverHeaderPolicy() {}
+
+func NewCustomServerHeaderPolicy(headerName string) ServerHeaderPolicy {
+	return customServerHeaderPolicy{
+		headerName,
+	}
+}
+
+func (p customServerHeaderPolicy) HeaderName() string {
+	return p.headerName
+}
+
+type disabledServerHeaderPolicy struct{}
+
+func (disabledServerHeaderPolicy) serverHeaderPolicy() {}
+
+func NewDisabledServerHeaderPolicy() ServerHeaderPolicy {
+	return disabledServerHeaderPolicy{}
+}
+
+type verifyOnceServerHeaderPolicy struct{}
+
+func (verifyOnceServerHeaderPolicy) serverHeaderPolicy() {}
+
+func NewVerifyOnceServerHeaderPolicy() ServerHeaderPolicy {
+	return verifyOnceServerHeaderPolicy{}
+}
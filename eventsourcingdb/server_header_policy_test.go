@@ -0,0 +1,149 @@
+package eventsourcingdb_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestServerHeaderPolicy(t *testing.T) {
+	// newGatewayServer simulates a gateway that rewrites the Server header of
+	// all responses, except for pings if pingServerHeader is set.
+	newGatewayServer := func(t *testing.T, pingServerHeader string, pings *atomic.Int32) *url.URL {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Server", "nginx")
+			w.Header().Set("X-Upstream-Server", "EventSourcingDB/1.0.0")
+			w.Header().Set("Content-Type", "application/json")
+
+			switch r.URL.Path {
+			case "/api/v1/ping":
+				pings.Add(1)
+				if pingServerHeader != "" {
+					w.Header().Set("Server", pingServerHeader)
+				}
+				fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.ping-received"}`)
+			case "/api/v1/verify-api-token":
+				fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.api-token-verified"}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		baseURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		return baseURL
+	}
+
+	t.Run("rejects a rewritten server header by default", func(t *testing.T) {
+		var pings atomic.Int32
+		baseURL := newGatewayServer(t, "", &pings)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.Error(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.Error(t, err)
+	})
+
+	t.Run("validates a custom header", func(t *testing.T) {
+		var pings atomic.Int32
+		baseURL := newGatewayServer(t, "", &pings)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewCustomServerHeaderPolicy("X-Upstream-Server")),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", serverInfo.RawVersion)
+	})
+
+	t.Run("skips the validation if it is disabled", func(t *testing.T) {
+		var pings atomic.Int32
+		baseURL := newGatewayServer(t, "", &pings)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewDisabledServerHeaderPolicy()),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+
+	t.Run("verifies the server once using a ping", func(t *testing.T) {
+		var pings atomic.Int32
+		baseURL := newGatewayServer(t, "EventSourcingDB/1.0.0", &pings)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewVerifyOnceServerHeaderPolicy()),
+		)
+		require.NoError(t, err)
+
+		for range 3 {
+			err = client.VerifyAPIToken()
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), pings.Load())
+	})
+
+	t.Run("fails if the server can not be verified once", func(t *testing.T) {
+		var pings atomic.Int32
+		baseURL := newGatewayServer(t, "", &pings)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewVerifyOnceServerHeaderPolicy()),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for an invalid policy", func(t *testing.T) {
+		baseURL, err := url.Parse("http://localhost:3000")
+		require.NoError(t, err)
+
+		_, err = eventsourcingdb.NewClient(baseURL, "secret", eventsourcingdb.WithServerHeaderPolicy(nil))
+		assert.Error(t, err)
+
+		_, err = eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerHeaderPolicy(eventsourcingdb.NewCustomServerHeaderPolicy("")),
+		)
+		assert.Error(t, err)
+	})
+}
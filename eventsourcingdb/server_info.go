@@ -0,0 +1,82 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	MinimumSupportedServerVersion = "1.0.0"
+	MaximumSupportedServerVersion = "2.0.0"
+)
+
+type ServerInfo struct {
+	RawVersion string
+	// Version is nil if the server reports a version that is not a semantic
+	// version, which is the case for preview and development builds.
+	Version *ServerVersion
+}
+
+func newServerInfo(rawVersion string) ServerInfo {
+	serverInfo := ServerInfo{
+		RawVersion: rawVersion,
+	}
+
+	version, err := ParseServerVersion(rawVersion)
+	if err == nil {
+		serverInfo.Version = &version
+	}
+
+	return serverInfo
+}
+
+// IsCompatible reports whether the server version is within the range of
+// supported versions, where the minimum is inclusive and the maximum is
+// exclusive. Servers that do not report a semantic version are assumed to be
+// compatible, since there is nothing to compare against.
+func (i ServerInfo) IsCompatible() bool {
+	if i.Version == nil {
+		return true
+	}
+
+	minimumVersion, _ := ParseServerVersion(MinimumSupportedServerVersion)
+	maximumVersion, _ := ParseServerVersion(MaximumSupportedServerVersion)
+
+	return i.Version.Compare(minimumVersion) >= 0 && i.Version.Compare(maximumVersion) < 0
+}
+
+// ServerInfo returns the info of the server that the next request is sent to.
+// If the client fails over to another endpoint, the result changes
+// accordingly.
+func (c *Client) ServerInfo(ctx context.Context) (ServerInfo, error) {
+	var err error
+	for _, endpoint := range c.peekEndpoints(ctx, "/api/v1/ping") {
+		var serverInfo ServerInfo
+		serverInfo, err = c.getEndpointServerInfo(ctx, endpoint)
+		if err == nil {
+			return serverInfo, nil
+		}
+	}
+
+	return ServerInfo{}, fmt.Errorf("failed to get server info: %w", err)
+}
+
+func (c *Client) getEndpointServerInfo(ctx context.Context, endpoint *endpoint) (ServerInfo, error) {
+	if serverInfo := endpoint.serverInfo.Load(); serverInfo != nil {
+		return *serverInfo, nil
+	}
+
+	err := c.ping(ctx, endpoint)
+	if err != nil {
+		return ServerInfo{}, err
+	}
+
+	// Depending on the server header policy, the server may not report its
+	// version at all.
+	serverInfo := endpoint.serverInfo.Load()
+	if serverInfo == nil {
+		return newServerInfo(""), nil
+	}
+
+	return *serverInfo, nil
+}
@@ -0,0 +1,202 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func newServerWithVersion(t *testing.T, version string, requests *atomic.Int32) *url.URL {
+	t.Helper()
+
+	return newStubServer(t, stubServerOptions{
+		Version: version,
+		Middleware: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				next.ServeHTTP(w, r)
+			})
+		},
+	})
+}
+
+func TestServerInfo(t *testing.T) {
+	t.Run("returns the parsed server version", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServerWithVersion(t, "1.4.2", &requests)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.2", serverInfo.RawVersion)
+		require.NotNil(t, serverInfo.Version)
+		assert.Equal(t, eventsourcingdb.ServerVersion{Major: 1, Minor: 4, Patch: 2}, *serverInfo.Version)
+		assert.True(t, serverInfo.IsCompatible())
+
+		// The server info is cached, so asking again does not send another
+		// request.
+		_, err = client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("treats a version that is not semantic as compatible", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServerWithVersion(t, "preview", &requests)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "preview", serverInfo.RawVersion)
+		assert.Nil(t, serverInfo.Version)
+		assert.True(t, serverInfo.IsCompatible())
+	})
+
+	t.Run("tracks the server version per endpoint", func(t *testing.T) {
+		var isPrimaryDown atomic.Bool
+		primaryURL := newStubServer(t, stubServerOptions{
+			Version: "1.4.2",
+			Middleware: func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if isPrimaryDown.Load() {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+
+					next.ServeHTTP(w, r)
+				})
+			},
+		})
+
+		var secondaryRequests atomic.Int32
+		secondaryURL := newServerWithVersion(t, "0.9.0", &secondaryRequests)
+
+		client, err := eventsourcingdb.NewClient(
+			primaryURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondaryURL),
+			eventsourcingdb.WithServerVersionCheck(eventsourcingdb.ServerVersionCheckStrict),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.2", serverInfo.RawVersion)
+
+		isPrimaryDown.Store(true)
+
+		// After failing over, the version of the secondary server is checked,
+		// instead of relying on the version of the primary one.
+		err = client.VerifyAPIToken()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrIncompatibleServerVersion)
+
+		serverInfo, err = client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "0.9.0", serverInfo.RawVersion)
+
+		// Only pings reached the secondary server.
+		assert.Equal(t, int32(1), secondaryRequests.Load())
+	})
+
+	t.Run("does not advance the round robin selection", func(t *testing.T) {
+		var primaryRequests, secondaryRequests atomic.Int32
+		primaryURL := newServerWithVersion(t, "1.4.2", &primaryRequests)
+		secondaryURL := newServerWithVersion(t, "1.5.0", &secondaryRequests)
+
+		client, err := eventsourcingdb.NewClient(
+			primaryURL,
+			"secret",
+			eventsourcingdb.WithFailoverURLs(secondaryURL),
+			eventsourcingdb.WithEndpointSelection(eventsourcingdb.EndpointSelectionRoundRobin),
+		)
+		require.NoError(t, err)
+
+		for range 3 {
+			serverInfo, err := client.ServerInfo(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "1.4.2", serverInfo.RawVersion)
+		}
+
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "1.5.0", serverInfo.RawVersion)
+	})
+
+	t.Run("warns once about an incompatible server version", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServerWithVersion(t, "2.0.0", &requests)
+
+		var warnings []error
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerVersionWarningHandler(func(err error) {
+				warnings = append(warnings, err)
+			}),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+
+		require.Len(t, warnings, 1)
+		assert.ErrorIs(t, warnings[0], eventsourcingdb.ErrIncompatibleServerVersion)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("rejects requests to an incompatible server in strict mode", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServerWithVersion(t, "0.9.0", &requests)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithServerVersionCheck(eventsourcingdb.ServerVersionCheckStrict),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.ErrorIs(t, err, eventsourcingdb.ErrIncompatibleServerVersion)
+
+		// Only the ping to get the server version reached the server.
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("returns the server version of a running server", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().WithImageTag(imageVersion)
+		container.Start(ctx)
+		defer container.Stop(ctx)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+
+		serverInfo, err := client.ServerInfo(ctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, serverInfo.RawVersion)
+		assert.True(t, serverInfo.IsCompatible())
+	})
+}
@@ -0,0 +1,79 @@
+package eventsourcingdb
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var serverVersionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+type ServerVersion struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string
+}
+
+func ParseServerVersion(version string) (ServerVersion, error) {
+	matches := serverVersionRegex.FindStringSubmatch(version)
+	if matches == nil {
+		return ServerVersion{}, fmt.Errorf("failed to parse server version '%s', expected a semantic version", version)
+	}
+
+	major, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	minor, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	patch, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return ServerVersion{}, err
+	}
+
+	return ServerVersion{
+		Major:      major,
+		Minor:      minor,
+		Patch:      patch,
+		PreRelease: matches[4],
+	}, nil
+}
+
+// Compare returns -1, 0, or +1 depending on whether v is lower than, equal to,
+// or greater than other. As in semantic versioning, a pre-release is lower
+// than the corresponding release. Pre-release identifiers are compared as
+// plain strings, which is sufficient for the tags the server uses.
+func (v ServerVersion) Compare(other ServerVersion) int {
+	if result := cmp.Compare(v.Major, other.Major); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(v.Minor, other.Minor); result != 0 {
+		return result
+	}
+	if result := cmp.Compare(v.Patch, other.Patch); result != 0 {
+		return result
+	}
+
+	switch {
+	case v.PreRelease == other.PreRelease:
+		return 0
+	case v.PreRelease == "":
+		return 1
+	case other.PreRelease == "":
+		return -1
+	default:
+		return cmp.Compare(v.PreRelease, other.PreRelease)
+	}
+}
+
+func (v ServerVersion) String() string {
+	if v.PreRelease != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.PreRelease)
+	}
+
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
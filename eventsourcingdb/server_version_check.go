@@ -0,0 +1,90 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+var ErrIncompatibleServerVersion = errors.New("incompatible server version")
+
+type ServerVersionCheck string
+
+const (
+	ServerVersionCheckDisabled ServerVersionCheck = "disabled"
+	ServerVersionCheckWarn     ServerVersionCheck = "warn"
+	ServerVersionCheckStrict   ServerVersionCheck = "strict"
+)
+
+func defaultServerVersionWarningHandler(err error) {
+	slog.Warn("EventSourcingDB server version is not supported by this client", "error", err)
+}
+
+// checkServerVersion is called before every request is sent to an endpoint.
+// In strict mode, it fetches the server info of the endpoint if it is not
+// known yet, so that no request is ever sent to an incompatible server.
+func (c *Client) checkServerVersion(ctx context.Context, endpoint *endpoint) error {
+	if c.serverVersionCheck != ServerVersionCheckStrict {
+		return nil
+	}
+
+	serverInfo, err := c.getEndpointServerInfo(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	return getServerVersionError(serverInfo)
+}
+
+// recordServerVersion is called for every response. Since every response
+// carries the server version, the client learns about it without any
+// additional request, and warns the first time it sees an incompatible one.
+func (c *Client) recordServerVersion(endpoint *endpoint, response *http.Response) {
+	if endpoint.serverInfo.Load() != nil {
+		return
+	}
+
+	rawVersion, err := internal.GetServerVersion(response, c.getServerHeaderName())
+	if err != nil {
+		return
+	}
+
+	c.recordServerInfo(endpoint, rawVersion)
+}
+
+func (c *Client) recordServerInfo(endpoint *endpoint, rawVersion string) {
+	if rawVersion == "" {
+		return
+	}
+
+	serverInfo := newServerInfo(rawVersion)
+	previousServerInfo := endpoint.serverInfo.Swap(&serverInfo)
+	if previousServerInfo != nil && previousServerInfo.RawVersion == rawVersion {
+		return
+	}
+
+	if c.serverVersionCheck == ServerVersionCheckWarn {
+		err := getServerVersionError(serverInfo)
+		if err != nil {
+			c.serverVersionWarningHandler(err)
+		}
+	}
+}
+
+func getServerVersionError(serverInfo ServerInfo) error {
+	if serverInfo.IsCompatible() {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: server version '%s' is not in the supported range from '%s' (inclusive) to '%s' (exclusive)",
+		ErrIncompatibleServerVersion,
+		serverInfo.RawVersion,
+		MinimumSupportedServerVersion,
+		MaximumSupportedServerVersion,
+	)
+}
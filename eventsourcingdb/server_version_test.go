@@ -0,0 +1,55 @@
+package eventsourcingdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	t.Run("parses a semantic version", func(t *testing.T) {
+		version, err := eventsourcingdb.ParseServerVersion("1.2.3")
+		require.NoError(t, err)
+		assert.Equal(t, eventsourcingdb.ServerVersion{Major: 1, Minor: 2, Patch: 3}, version)
+		assert.Equal(t, "1.2.3", version.String())
+	})
+
+	t.Run("parses a semantic version with pre-release and build metadata", func(t *testing.T) {
+		version, err := eventsourcingdb.ParseServerVersion("v1.2.3-rc.1+abc")
+		require.NoError(t, err)
+		assert.Equal(t, eventsourcingdb.ServerVersion{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"}, version)
+		assert.Equal(t, "1.2.3-rc.1", version.String())
+	})
+
+	t.Run("returns an error for a version that is not semantic", func(t *testing.T) {
+		for _, version := range []string{"", "preview", "1.2", "1.2.x"} {
+			_, err := eventsourcingdb.ParseServerVersion(version)
+			assert.Error(t, err, version)
+		}
+	})
+}
+
+func TestServerVersionCompare(t *testing.T) {
+	for _, testCase := range []struct {
+		left     string
+		right    string
+		expected int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "2.0.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"1.0.1", "1.0.0", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-rc.1", "1.0.0-rc.2", -1},
+	} {
+		left, err := eventsourcingdb.ParseServerVersion(testCase.left)
+		require.NoError(t, err)
+		right, err := eventsourcingdb.ParseServerVersion(testCase.right)
+		require.NoError(t, err)
+
+		assert.Equal(t, testCase.expected, left.Compare(right), "%s <=> %s", testCase.left, testCase.right)
+	}
+}
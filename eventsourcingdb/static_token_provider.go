@@ -0,0 +1,21 @@
+package eventsourcingdb
+
+import "context"
+
+type staticTokenProvider struct {
+	token string
+}
+
+func NewStaticTokenProvider(token string) TokenProvider {
+	return staticTokenProvider{
+		token,
+	}
+}
+
+func (p staticTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return p.token, nil
+}
+
+func (p staticTokenProvider) RefreshToken(ctx context.Context) (string, error) {
+	return p.token, nil
+}
@@ -0,0 +1,78 @@
+package eventsourcingdb_test
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubServerOptions configure a stub server. Version defaults to "test".
+// Routes are looked up by path, and answer pings and API token verifications
+// by default. Middleware, if given, wraps the routes, e.g. to count requests
+// or to fail all of them.
+type stubServerOptions struct {
+	Version    string
+	Routes     map[string]http.HandlerFunc
+	Middleware func(next http.Handler) http.Handler
+}
+
+// newUnstartedStubServer returns a server that responds like EventSourcingDB,
+// for tests that need to control the responses in ways a real server can not,
+// such as failures. It is not started, e.g. so that it can use TLS.
+func newUnstartedStubServer(tb testing.TB, options stubServerOptions) *httptest.Server {
+	tb.Helper()
+
+	version := options.Version
+	if version == "" {
+		version = "test"
+	}
+
+	routes := map[string]http.HandlerFunc{
+		"/api/v1/ping": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.ping-received"}`)
+		},
+		"/api/v1/verify-api-token": func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.api-token-verified"}`)
+		},
+	}
+	maps.Copy(routes, options.Routes)
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		route(w, r)
+	})
+	if options.Middleware != nil {
+		handler = options.Middleware(handler)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "EventSourcingDB/"+version)
+		handler.ServeHTTP(w, r)
+	}))
+	tb.Cleanup(server.Close)
+
+	return server
+}
+
+// newStubServer starts a stub server, and returns its base URL.
+func newStubServer(tb testing.TB, options stubServerOptions) *url.URL {
+	tb.Helper()
+
+	server := newUnstartedStubServer(tb, options)
+	server.Start()
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(tb, err)
+
+	return baseURL
+}
@@ -0,0 +1,115 @@
+package eventsourcingdb
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func WithCACertificate(certificatePEM []byte) ClientOption {
+	return func(c *Client) error {
+		tlsConfig := c.getTLSConfig()
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = x509.NewCertPool()
+		}
+
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(certificatePEM) {
+			return errors.New("failed to parse CA certificate, expected PEM encoded certificates")
+		}
+
+		return nil
+	}
+}
+
+func WithCACertificateFile(path string) ClientOption {
+	return func(c *Client) error {
+		certificatePEM, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate file: %w", err)
+		}
+
+		return WithCACertificate(certificatePEM)(c)
+	}
+}
+
+func WithClientCertificate(certificate tls.Certificate) ClientOption {
+	return func(c *Client) error {
+		tlsConfig := c.getTLSConfig()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, certificate)
+
+		return nil
+	}
+}
+
+func WithClientCertificateFiles(certificateFile string, privateKeyFile string) ClientOption {
+	return func(c *Client) error {
+		certificate, err := tls.LoadX509KeyPair(certificateFile, privateKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		return WithClientCertificate(certificate)(c)
+	}
+}
+
+// WithCertificatePinning restricts the accepted server certificates to those
+// whose chain contains a certificate with one of the given public key
+// fingerprints. A fingerprint is the hex encoded SHA-256 hash of the DER
+// encoded SubjectPublicKeyInfo; colons are ignored. Pinning is checked in
+// addition to the regular certificate verification, not instead of it, and
+// only the verified chains are considered, since a server may send arbitrary
+// additional certificates.
+func WithCertificatePinning(fingerprints ...string) ClientOption {
+	return func(c *Client) error {
+		if len(fingerprints) == 0 {
+			return errors.New("at least one certificate fingerprint must be given")
+		}
+
+		pins := make(map[string]struct{}, len(fingerprints))
+		for _, fingerprint := range fingerprints {
+			pin := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+
+			pinBytes, err := hex.DecodeString(pin)
+			if err != nil || len(pinBytes) != sha256.Size {
+				return fmt.Errorf("certificate fingerprint '%s' must be a hex encoded SHA-256 hash", fingerprint)
+			}
+
+			pins[pin] = struct{}{}
+		}
+
+		tlsConfig := c.getTLSConfig()
+		tlsConfig.VerifyConnection = func(connectionState tls.ConnectionState) error {
+			for _, chain := range connectionState.VerifiedChains {
+				for _, certificate := range chain {
+					if _, ok := pins[GetCertificateFingerprint(certificate)]; ok {
+						return nil
+					}
+				}
+			}
+
+			return errors.New("server certificate does not match any pinned fingerprint")
+		}
+
+		return nil
+	}
+}
+
+func GetCertificateFingerprint(certificate *x509.Certificate) string {
+	fingerprint := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+func (c *Client) getTLSConfig() *tls.Config {
+	if c.tlsConfig == nil {
+		c.tlsConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	return c.tlsConfig
+}
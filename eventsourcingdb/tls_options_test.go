@@ -0,0 +1,278 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestTLSOptions(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "EventSourcingDB/test")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.ping-received"}`)
+	})
+
+	startServer := func(t *testing.T, server *httptest.Server) (*url.URL, []byte) {
+		t.Helper()
+
+		if server.URL == "" {
+			server.StartTLS()
+		}
+		t.Cleanup(server.Close)
+
+		baseURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		certificatePEM := pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.Certificate().Raw,
+		})
+
+		return baseURL, certificatePEM
+	}
+
+	t.Run("rejects a server certificate from an unknown CA", func(t *testing.T) {
+		baseURL, _ := startServer(t, httptest.NewUnstartedServer(handler))
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.Error(t, err)
+	})
+
+	t.Run("trusts a custom CA certificate", func(t *testing.T) {
+		baseURL, certificatePEM := startServer(t, httptest.NewUnstartedServer(handler))
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificatePEM),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+	})
+
+	t.Run("reads the CA certificate from a file", func(t *testing.T) {
+		baseURL, certificatePEM := startServer(t, httptest.NewUnstartedServer(handler))
+
+		certificateFile := filepath.Join(t.TempDir(), "ca.pem")
+		err := os.WriteFile(certificateFile, certificatePEM, 0600)
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificateFile(certificateFile),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+	})
+
+	t.Run("returns an error for an invalid CA certificate", func(t *testing.T) {
+		baseURL, err := url.Parse("https://localhost:3000")
+		require.NoError(t, err)
+
+		_, err = eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate([]byte("invalid")),
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("accepts a server certificate that matches a pinned fingerprint", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(handler)
+		baseURL, certificatePEM := startServer(t, server)
+
+		fingerprint := eventsourcingdb.GetCertificateFingerprint(server.Certificate())
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificatePEM),
+			eventsourcingdb.WithCertificatePinning(fingerprint),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a server certificate that does not match a pinned fingerprint", func(t *testing.T) {
+		baseURL, certificatePEM := startServer(t, httptest.NewUnstartedServer(handler))
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificatePEM),
+			eventsourcingdb.WithCertificatePinning(strings.Repeat("ab", 32)),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects a pinned certificate that is not part of the verified chain", func(t *testing.T) {
+		certificateAuthority, err := internal.NewCertificateAuthority()
+		require.NoError(t, err)
+		serverCertificatePEM, serverPrivateKeyPEM, err := certificateAuthority.IssueCertificate(
+			"localhost",
+			[]string{"127.0.0.1"},
+		)
+		require.NoError(t, err)
+
+		otherCertificateAuthority, err := internal.NewCertificateAuthority()
+		require.NoError(t, err)
+		pinnedCertificatePEM, _, err := otherCertificateAuthority.IssueCertificate("pinned", nil)
+		require.NoError(t, err)
+
+		pinnedBlock, _ := pem.Decode(pinnedCertificatePEM)
+		require.NotNil(t, pinnedBlock)
+		pinnedCertificate, err := x509.ParseCertificate(pinnedBlock.Bytes)
+		require.NoError(t, err)
+
+		// The server sends the pinned certificate along with its own chain,
+		// which it is able to do since certificates are public.
+		serverCertificate, err := tls.X509KeyPair(
+			append(serverCertificatePEM, pinnedCertificatePEM...),
+			serverPrivateKeyPEM,
+		)
+		require.NoError(t, err)
+		require.Len(t, serverCertificate.Certificate, 2)
+
+		server := httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			MinVersion:   tls.VersionTLS12,
+		}
+		server.StartTLS()
+		baseURL, _ := startServer(t, server)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificateAuthority.CertificatePEM),
+			eventsourcingdb.WithCertificatePinning(eventsourcingdb.GetCertificateFingerprint(pinnedCertificate)),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for a malformed fingerprint", func(t *testing.T) {
+		baseURL, err := url.Parse("https://localhost:3000")
+		require.NoError(t, err)
+
+		_, err = eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCertificatePinning("not-a-fingerprint"),
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("authenticates with a client certificate", func(t *testing.T) {
+		certificateAuthority, err := internal.NewCertificateAuthority()
+		require.NoError(t, err)
+
+		serverCertificatePEM, serverPrivateKeyPEM, err := certificateAuthority.IssueCertificate(
+			"localhost",
+			[]string{"127.0.0.1"},
+		)
+		require.NoError(t, err)
+		serverCertificate, err := tls.X509KeyPair(serverCertificatePEM, serverPrivateKeyPEM)
+		require.NoError(t, err)
+
+		clientCAs := x509.NewCertPool()
+		clientCAs.AppendCertsFromPEM(certificateAuthority.CertificatePEM)
+
+		server := httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+			MinVersion:   tls.VersionTLS12,
+		}
+		server.StartTLS()
+		baseURL, _ := startServer(t, server)
+
+		clientCertificatePEM, clientPrivateKeyPEM, err := certificateAuthority.IssueCertificate("client", nil)
+		require.NoError(t, err)
+
+		directory := t.TempDir()
+		clientCertificateFile := filepath.Join(directory, "client.pem")
+		clientPrivateKeyFile := filepath.Join(directory, "client-key.pem")
+		require.NoError(t, os.WriteFile(clientCertificateFile, clientCertificatePEM, 0600))
+		require.NoError(t, os.WriteFile(clientPrivateKeyFile, clientPrivateKeyPEM, 0600))
+
+		clientWithoutCertificate, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificateAuthority.CertificatePEM),
+		)
+		require.NoError(t, err)
+
+		err = clientWithoutCertificate.Ping()
+		assert.Error(t, err)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"secret",
+			eventsourcingdb.WithCACertificate(certificateAuthority.CertificatePEM),
+			eventsourcingdb.WithClientCertificateFiles(clientCertificateFile, clientPrivateKeyFile),
+		)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+	})
+
+	t.Run("connects to a container with HTTPS enabled", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().
+			WithImageTag(imageVersion).
+			WithHTTPS()
+		err = container.Start(ctx)
+		require.NoError(t, err)
+		defer container.Stop(ctx)
+
+		baseURL, err := container.GetBaseURL(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "https", baseURL.Scheme)
+
+		client, err := container.GetClient(ctx)
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+}
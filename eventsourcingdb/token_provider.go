@@ -0,0 +1,8 @@
+package eventsourcingdb
+
+import "context"
+
+type TokenProvider interface {
+	GetToken(ctx context.Context) (string, error)
+	RefreshToken(ctx context.Context) (string, error)
+}
@@ -0,0 +1,219 @@
+package eventsourcingdb_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestTokenProvider(t *testing.T) {
+	t.Run("returns the static token", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenProvider := eventsourcingdb.NewStaticTokenProvider("secret")
+
+		token, err := tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", token)
+
+		token, err = tokenProvider.RefreshToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", token)
+	})
+
+	t.Run("reads the token from an environment variable on every call", func(t *testing.T) {
+		ctx := context.Background()
+
+		t.Setenv("ESDB_TEST_API_TOKEN", "first")
+		tokenProvider := eventsourcingdb.NewEnvironmentTokenProvider("ESDB_TEST_API_TOKEN")
+
+		token, err := tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "first", token)
+
+		t.Setenv("ESDB_TEST_API_TOKEN", "second")
+
+		token, err = tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "second", token)
+	})
+
+	t.Run("returns an error if the environment variable is not set", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenProvider := eventsourcingdb.NewEnvironmentTokenProvider("ESDB_TEST_NON_EXISTENT_API_TOKEN")
+
+		_, err := tokenProvider.GetToken(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("picks up changes to the token file", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		err := os.WriteFile(tokenFile, []byte("first\n"), 0600)
+		require.NoError(t, err)
+
+		tokenProvider := eventsourcingdb.NewFileTokenProvider(tokenFile)
+
+		token, err := tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "first", token)
+
+		err = os.WriteFile(tokenFile, []byte("second\n"), 0600)
+		require.NoError(t, err)
+
+		// Some file systems have a coarse modification time resolution, so
+		// the timestamp is moved explicitly to make the change detectable.
+		modTime := time.Now().Add(time.Minute)
+		err = os.Chtimes(tokenFile, modTime, modTime)
+		require.NoError(t, err)
+
+		token, err = tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "second", token)
+	})
+
+	t.Run("returns an error if the token file is empty", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		err := os.WriteFile(tokenFile, []byte("\n"), 0600)
+		require.NoError(t, err)
+
+		tokenProvider := eventsourcingdb.NewFileTokenProvider(tokenFile)
+
+		_, err = tokenProvider.GetToken(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("calls the callback for every token", func(t *testing.T) {
+		ctx := context.Background()
+
+		var calls atomic.Int32
+		tokenProvider := eventsourcingdb.NewCallbackTokenProvider(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("token-%d", calls.Add(1)), nil
+		})
+
+		token, err := tokenProvider.GetToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", token)
+
+		token, err = tokenProvider.RefreshToken(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "token-2", token)
+	})
+}
+
+func TestClientWithTokenProvider(t *testing.T) {
+	newServer := func(t *testing.T, validToken string, requests *atomic.Int32) *url.URL {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.Header().Set("Server", "EventSourcingDB/test")
+
+			if r.Header.Get("Authorization") != "Bearer "+validToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.api-token-verified"}`)
+		}))
+		t.Cleanup(server.Close)
+
+		baseURL, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		return baseURL
+	}
+
+	t.Run("refreshes the token once if the server responds with 401", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServer(t, "rotated", &requests)
+
+		currentToken := "outdated"
+		tokenProvider := eventsourcingdb.NewCallbackTokenProvider(func(ctx context.Context) (string, error) {
+			token := currentToken
+			currentToken = "rotated"
+			return token, nil
+		})
+
+		client, err := eventsourcingdb.NewClient(baseURL, "", eventsourcingdb.WithTokenProvider(tokenProvider))
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("does not retry if the refreshed token is unchanged", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServer(t, "secret", &requests)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "invalid")
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("fails if the refreshed token is still rejected", func(t *testing.T) {
+		var requests atomic.Int32
+		baseURL := newServer(t, "secret", &requests)
+
+		var calls atomic.Int32
+		tokenProvider := eventsourcingdb.NewCallbackTokenProvider(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("invalid-%d", calls.Add(1)), nil
+		})
+
+		client, err := eventsourcingdb.NewClient(baseURL, "", eventsourcingdb.WithTokenProvider(tokenProvider))
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.Error(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("uses the token provider against a running server", func(t *testing.T) {
+		ctx := context.Background()
+
+		imageVersion, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+
+		container := eventsourcingdb.NewContainer().WithImageTag(imageVersion)
+		container.Start(ctx)
+		defer container.Stop(ctx)
+
+		baseURL, err := container.GetBaseURL(ctx)
+		require.NoError(t, err)
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		err = os.WriteFile(tokenFile, []byte(container.GetAPIToken()), 0600)
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(
+			baseURL,
+			"",
+			eventsourcingdb.WithTokenProvider(eventsourcingdb.NewFileTokenProvider(tokenFile)),
+		)
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+	})
+}
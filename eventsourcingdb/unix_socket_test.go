@@ -0,0 +1,116 @@
+package eventsourcingdb_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+func TestUnixSocket(t *testing.T) {
+	startServer := func(t *testing.T) (string, func() []string) {
+		t.Helper()
+
+		// Unix socket paths are limited to roughly 100 bytes, which the
+		// directory returned by t.TempDir may already exceed.
+		directory, err := os.MkdirTemp("", "esdb")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(directory) })
+
+		socketPath := filepath.Join(directory, "esdb.sock")
+		listener, err := net.Listen("unix", socketPath)
+		require.NoError(t, err)
+
+		var mutex sync.Mutex
+		var paths []string
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			paths = append(paths, r.URL.Path)
+			mutex.Unlock()
+
+			w.Header().Set("Server", "EventSourcingDB/test")
+
+			switch r.URL.Path {
+			case "/api/v1/ping":
+				fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.ping-received"}`)
+			case "/api/v1/verify-api-token":
+				fmt.Fprint(w, `{"type":"io.eventsourcingdb.api.api-token-verified"}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		server.Listener.Close()
+		server.Listener = listener
+		server.Start()
+		t.Cleanup(server.Close)
+
+		getPaths := func() []string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return paths
+		}
+
+		return socketPath, getPaths
+	}
+
+	t.Run("connects to a Unix socket given as base URL", func(t *testing.T) {
+		socketPath, paths := startServer(t)
+
+		baseURL, err := url.Parse("unix://" + socketPath)
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		err = client.Ping()
+		require.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/api/v1/ping", "/api/v1/verify-api-token"}, paths())
+	})
+
+	t.Run("connects to a Unix socket given as option", func(t *testing.T) {
+		socketPath, paths := startServer(t)
+
+		baseURL, err := url.Parse("http://esdb.local")
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret", eventsourcingdb.WithUnixSocket(socketPath))
+		require.NoError(t, err)
+
+		err = client.Ping()
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"/api/v1/ping"}, paths())
+	})
+
+	t.Run("returns an error if the socket does not exist", func(t *testing.T) {
+		baseURL, err := url.Parse("unix:///non-existent/esdb.sock")
+		require.NoError(t, err)
+
+		client, err := eventsourcingdb.NewClient(baseURL, "secret")
+		require.NoError(t, err)
+
+		err = client.Ping()
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for a Unix socket base URL with a host", func(t *testing.T) {
+		baseURL, err := url.Parse("unix://var/run/esdb.sock")
+		require.NoError(t, err)
+
+		_, err = eventsourcingdb.NewClient(baseURL, "secret")
+		assert.Error(t, err)
+	})
+}
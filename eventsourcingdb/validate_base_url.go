@@ -0,0 +1,53 @@
+package eventsourcingdb
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+func validateBaseURL(baseURL *url.URL) error {
+	if baseURL == nil {
+		return errors.New("base URL must not be nil")
+	}
+
+	if baseURL.Scheme == "unix" {
+		if baseURL.Host != "" {
+			return fmt.Errorf("base URL for a Unix socket must not contain a host, got '%s', use 'unix:///path/to/socket' instead", baseURL.Host)
+		}
+		if baseURL.Path == "" {
+			return errors.New("base URL for a Unix socket must contain the socket path")
+		}
+		if baseURL.RawQuery != "" || baseURL.Fragment != "" {
+			return errors.New("base URL must not contain a query or a fragment")
+		}
+
+		return nil
+	}
+
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" {
+		return fmt.Errorf("base URL must use the 'http', 'https', or 'unix' scheme, got '%s'", baseURL.Scheme)
+	}
+
+	if baseURL.Host == "" {
+		return errors.New("base URL must contain a host")
+	}
+
+	if baseURL.User != nil {
+		return errors.New("base URL must not contain credentials, provide the API token separately")
+	}
+
+	// API paths are resolved against the base URL as absolute paths, so any
+	// path on the base URL would be silently dropped instead of being used as
+	// a prefix. Rejecting it up front avoids requests ending up somewhere else
+	// than intended.
+	if baseURL.Path != "" && baseURL.Path != "/" {
+		return fmt.Errorf("base URL must not contain a path, got '%s'", baseURL.Path)
+	}
+
+	if baseURL.RawQuery != "" || baseURL.Fragment != "" {
+		return errors.New("base URL must not contain a query or a fragment")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package eventsourcingdb
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func (c *Client) getServerHeaderName() string {
+	if policy, ok := c.serverHeaderPolicy.(customServerHeaderPolicy); ok {
+		return policy.HeaderName()
+	}
+
+	return "Server"
+}
+
+func (c *Client) validateServerHeader(response *http.Response) error {
+	switch c.serverHeaderPolicy.(type) {
+	case disabledServerHeaderPolicy, verifyOnceServerHeaderPolicy:
+		return nil
+	default:
+		return internal.ValidateServerHeader(response, c.getServerHeaderName())
+	}
+}
+
+// validatePingServerHeader validates the response to a ping. With the verify
+// once policy, pings are validated strictly, since they are what the server
+// is verified with.
+func (c *Client) validatePingServerHeader(response *http.Response) error {
+	if _, ok := c.serverHeaderPolicy.(verifyOnceServerHeaderPolicy); ok {
+		return internal.ValidateServerHeader(response, "Server")
+	}
+
+	return c.validateServerHeader(response)
+}
+
+func (c *Client) verifyServerOnce(ctx context.Context) error {
+	if _, ok := c.serverHeaderPolicy.(verifyOnceServerHeaderPolicy); !ok {
+		return nil
+	}
+	if c.isServerVerified.Load() {
+		return nil
+	}
+
+	var err error
+	for _, endpoint := range c.selectEndpoints(ctx, "/api/v1/ping") {
+		err = c.ping(ctx, endpoint)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
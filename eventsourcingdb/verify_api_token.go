@@ -1,6 +1,7 @@
 package eventsourcingdb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,30 +10,12 @@ import (
 )
 
 func (c *Client) VerifyAPIToken() error {
-	verifyAPITokenURL, err := c.getURL("/api/v1/verify-api-token")
-	if err != nil {
-		return err
-	}
-
-	request := &http.Request{
-		Method: http.MethodPost,
-		URL:    verifyAPITokenURL,
-		Header: http.Header{
-			"Authorization": []string{"Bearer " + c.apiToken},
-		},
-	}
-
-	response, err := http.DefaultClient.Do(request)
+	response, err := c.sendRequest(context.Background(), http.MethodPost, "/api/v1/verify-api-token", nil)
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	err = internal.ValidateServerHeader(response)
-	if err != nil {
-		return err
-	}
-
 	if response.StatusCode != http.StatusOK {
 		return fmt.Errorf("failed to verify API token, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
 	}
@@ -1,22 +1,15 @@
 package eventsourcingdb
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"time"
 
 	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
 )
 
 func (c *Client) WriteEvents(events []EventCandidate, preconditions []Precondition) ([]Event, error) {
-	writeEventsURL, err := c.getURL("/api/v1/write-events")
-	if err != nil {
-		return nil, err
-	}
-
 	type RequestBodyEvent struct {
 		Source      string  `json:"source"`
 		Subject     string  `json:"subject"`
@@ -77,29 +70,12 @@ func (c *Client) WriteEvents(events []EventCandidate, preconditions []Preconditi
 		return nil, err
 	}
 
-	requestBodyReader := io.NopCloser(bytes.NewReader(requestBodyJSON))
-
-	request := &http.Request{
-		Method: http.MethodPost,
-		URL:    writeEventsURL,
-		Header: http.Header{
-			"Authorization": []string{"Bearer " + c.apiToken},
-			"Content-Type":  []string{"application/json"},
-		},
-		Body: requestBodyReader,
-	}
-
-	response, err := http.DefaultClient.Do(request)
+	response, err := c.sendRequest(context.Background(), http.MethodPost, "/api/v1/write-events", requestBodyJSON)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 
-	err = internal.ValidateServerHeader(response)
-	if err != nil {
-		return nil, err
-	}
-
 	if response.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to write events, got HTTP status code '%d', expected '%d'", response.StatusCode, http.StatusOK)
 	}
@@ -112,26 +88,10 @@ func (c *Client) WriteEvents(events []EventCandidate, preconditions []Preconditi
 
 	writtenEvents := make([]Event, 0, len(cloudEvents))
 	for _, cloudEvent := range cloudEvents {
-		cloudEventTime, err := time.Parse(time.RFC3339Nano, cloudEvent.Time)
+		writtenEvent, err := NewEventFromCloudEvent(cloudEvent)
 		if err != nil {
 			return nil, err
 		}
-
-		writtenEvent := Event{
-			SpecVersion:     cloudEvent.SpecVersion,
-			ID:              cloudEvent.ID,
-			Time:            cloudEventTime,
-			Source:          cloudEvent.Source,
-			Subject:         cloudEvent.Subject,
-			Type:            cloudEvent.Type,
-			DataContentType: cloudEvent.DataContentType,
-			Data:            cloudEvent.Data,
-			Hash:            cloudEvent.Hash,
-			PredecessorHash: cloudEvent.PredecessorHash,
-			TraceParent:     cloudEvent.TraceParent,
-			TraceState:      cloudEvent.TraceState,
-			Signature:       cloudEvent.Signature,
-		}
 		writtenEvents = append(writtenEvents, writtenEvent)
 	}
 
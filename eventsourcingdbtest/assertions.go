@@ -0,0 +1,132 @@
+package eventsourcingdbtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+// AssertSubjectHasEvents checks that the given subject contains exactly the
+// given events, in order. Events of nested subjects are not taken into account.
+// Server-generated fields such as the ID, time, and hash are ignored.
+func AssertSubjectHasEvents(t testing.TB, reader eventsourcingdb.Reader, subject string, events ...eventsourcingdb.EventCandidate) {
+	t.Helper()
+
+	compareEvents(t, "events of "+subject, readSubjectEvents(t, reader, subject), events)
+}
+
+// AssertEventTypes checks that the events of the given subject have exactly
+// the given types, in order.
+func AssertEventTypes(t testing.TB, reader eventsourcingdb.Reader, subject string, eventTypes ...string) {
+	t.Helper()
+
+	actualEventTypes := []string{}
+	for _, event := range readSubjectEvents(t, reader, subject) {
+		actualEventTypes = append(actualEventTypes, event.Type)
+	}
+
+	if !slices.Equal(actualEventTypes, eventTypes) {
+		t.Errorf("expected event types of %s to be %v, got %v", subject, eventTypes, actualEventTypes)
+	}
+}
+
+// AssertDataEquals checks that the data of the given event equals the given
+// value once serialized to JSON, regardless of the order of object keys.
+func AssertDataEquals(t testing.TB, event eventsourcingdb.Event, data any) {
+	t.Helper()
+
+	isEqual, err := isDataEqual(event.Data, data)
+	if err != nil {
+		t.Errorf("failed to compare data of event %s: %v", event.ID, err)
+		return
+	}
+	if !isEqual {
+		expectedData, _ := json.Marshal(data)
+		t.Errorf("expected data of event %s to be %s, got %s", event.ID, expectedData, event.Data)
+	}
+}
+
+func readSubjectEvents(t testing.TB, reader eventsourcingdb.Reader, subject string) []eventsourcingdb.Event {
+	t.Helper()
+
+	events := []eventsourcingdb.Event{}
+	for event, err := range reader.ReadEvents(t.Context(), subject, eventsourcingdb.ReadEventsOptions{}) {
+		if err != nil {
+			t.Fatalf("failed to read events of %s: %v", subject, err)
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func compareEvents(t testing.TB, description string, actualEvents []eventsourcingdb.Event, expectedEvents []eventsourcingdb.EventCandidate) {
+	t.Helper()
+
+	if len(actualEvents) != len(expectedEvents) {
+		t.Errorf("expected %d %s, got %d", len(expectedEvents), description, len(actualEvents))
+	}
+
+	for i := range min(len(actualEvents), len(expectedEvents)) {
+		mismatch, err := getEventMismatch(actualEvents[i], expectedEvents[i])
+		if err != nil {
+			t.Errorf("failed to compare event %d of %s: %v", i, description, err)
+			continue
+		}
+		if mismatch != "" {
+			t.Errorf("event %d of %s does not match: %s", i, description, mismatch)
+		}
+	}
+}
+
+func getEventMismatch(actual eventsourcingdb.Event, expected eventsourcingdb.EventCandidate) (string, error) {
+	if actual.Source != expected.Source {
+		return fmt.Sprintf("expected source %q, got %q", expected.Source, actual.Source), nil
+	}
+	if actual.Subject != expected.Subject {
+		return fmt.Sprintf("expected subject %q, got %q", expected.Subject, actual.Subject), nil
+	}
+	if actual.Type != expected.Type {
+		return fmt.Sprintf("expected type %q, got %q", expected.Type, actual.Type), nil
+	}
+	if expected.TraceParent != nil && (actual.TraceParent == nil || *actual.TraceParent != *expected.TraceParent) {
+		return fmt.Sprintf("expected trace parent %q", *expected.TraceParent), nil
+	}
+	if expected.TraceState != nil && (actual.TraceState == nil || *actual.TraceState != *expected.TraceState) {
+		return fmt.Sprintf("expected trace state %q", *expected.TraceState), nil
+	}
+
+	isEqual, err := isDataEqual(actual.Data, expected.Data)
+	if err != nil {
+		return "", err
+	}
+	if !isEqual {
+		expectedData, _ := json.Marshal(expected.Data)
+		return fmt.Sprintf("expected data %s, got %s", expectedData, actual.Data), nil
+	}
+
+	return "", nil
+}
+
+func isDataEqual(actualData json.RawMessage, expectedData any) (bool, error) {
+	expectedJSON, err := json.Marshal(expectedData)
+	if err != nil {
+		return false, err
+	}
+
+	var actual, expected any
+	err = json.Unmarshal(actualData, &actual)
+	if err != nil {
+		return false, err
+	}
+	err = json.Unmarshal(expectedJSON, &expected)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(actual, expected), nil
+}
@@ -0,0 +1,104 @@
+package eventsourcingdbtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+	"unicode/utf8"
+)
+
+// Cassette contains the request/response pairs captured by a Recorder, so that
+// a Replayer can serve them without a running server.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   Payload     `json:"body,omitempty"`
+}
+
+// RecordedResponse contains the body of a response as the chunks the client
+// read, along with their offset from the moment the response headers arrived.
+// IsComplete reports whether the body was read to its end. If not, e.g. for a
+// stream that was aborted by the client, a replayed body stays open after the
+// last chunk until the request is cancelled.
+type RecordedResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Header     http.Header     `json:"header"`
+	Duration   time.Duration   `json:"duration"`
+	Chunks     []RecordedChunk `json:"chunks"`
+	IsComplete bool            `json:"isComplete"`
+}
+
+type RecordedChunk struct {
+	Offset time.Duration `json:"offset"`
+	Data   Payload       `json:"data"`
+}
+
+// Payload is serialized as a JSON string if it is valid UTF-8, which keeps
+// cassettes readable, and as an object with a base64 field otherwise, e.g. for
+// gzip-compressed bodies.
+type Payload []byte
+
+type binaryPayload struct {
+	Base64 []byte `json:"base64"`
+}
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(p) {
+		return json.Marshal(string(p))
+	}
+
+	return json.Marshal(binaryPayload{Base64: p})
+}
+
+func (p *Payload) UnmarshalJSON(data []byte) error {
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err == nil {
+		*p = Payload(text)
+		return nil
+	}
+
+	var binary binaryPayload
+	err = json.Unmarshal(data, &binary)
+	if err != nil {
+		return err
+	}
+
+	*p = binary.Base64
+	return nil
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	err = json.Unmarshal(content, &cassette)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cassette, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(content, '\n'), 0600)
+}
@@ -0,0 +1,5 @@
+// Package eventsourcingdbtest provides an in-memory fake of EventSourcingDB for
+// tests that should run without Docker, transports to record, replay, and
+// manipulate the requests of a client, helpers to test event-sourced logic,
+// and helpers to use a container in tests.
+package eventsourcingdbtest
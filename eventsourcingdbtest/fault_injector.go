@@ -0,0 +1,309 @@
+package eventsourcingdbtest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+var ErrInjectedConnectionReset = fmt.Errorf("injected fault: %w", syscall.ECONNRESET)
+
+// FaultInjector manipulates the requests of a client to test how code behaves
+// if the connection to the server is slow or breaks. By default, the configured
+// faults are injected into every request.
+type FaultInjector struct {
+	latency             time.Duration
+	statusCode          int
+	resetAfterBytes     int
+	truncateAfterLines  int
+	serverHeader        *string
+	operations          []eventsourcingdb.Operation
+	probability         float64
+	randomMutex         sync.Mutex
+	random              *rand.Rand
+	isDisabled          atomic.Bool
+	injectedFaultsCount atomic.Int64
+}
+
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{
+		resetAfterBytes:    -1,
+		truncateAfterLines: -1,
+		probability:        1,
+		random:             rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// WithLatency delays requests by the given duration before sending them.
+func (f *FaultInjector) WithLatency(latency time.Duration) *FaultInjector {
+	f.latency = latency
+	return f
+}
+
+// WithStatusCode responds to requests with the given status code, e.g. 503,
+// without sending them to the server.
+func (f *FaultInjector) WithStatusCode(statusCode int) *FaultInjector {
+	f.statusCode = statusCode
+	return f
+}
+
+// WithConnectionResetAfter fails reading a response body with
+// ErrInjectedConnectionReset once the given number of bytes has been read.
+func (f *FaultInjector) WithConnectionResetAfter(bytes int) *FaultInjector {
+	f.resetAfterBytes = bytes
+	return f
+}
+
+// WithTruncatedStreamAfter ends a response body in the middle of the line that
+// follows the given number of complete lines, as if an NDJSON stream broke off.
+func (f *FaultInjector) WithTruncatedStreamAfter(lines int) *FaultInjector {
+	f.truncateAfterLines = lines
+	return f
+}
+
+// WithServerHeader replaces the Server header of responses with the given
+// value. An empty value removes the header.
+func (f *FaultInjector) WithServerHeader(value string) *FaultInjector {
+	f.serverHeader = &value
+	return f
+}
+
+// ForOperations restricts the faults to requests of the given operations.
+// Pings are only affected if no operations are given.
+func (f *FaultInjector) ForOperations(operations ...eventsourcingdb.Operation) *FaultInjector {
+	f.operations = append(f.operations, operations...)
+	return f
+}
+
+// WithProbability injects the faults only into the given fraction of requests.
+// The seed makes the selection of requests reproducible.
+func (f *FaultInjector) WithProbability(probability float64, seed uint64) *FaultInjector {
+	f.probability = probability
+	f.random = rand.New(rand.NewPCG(seed, seed))
+	return f
+}
+
+// Wrap returns a transport that injects the faults into requests sent via the
+// given transport. It is meant to be used with eventsourcingdb.WithTransport.
+func (f *FaultInjector) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &faultInjectingTransport{
+		faultInjector: f,
+		next:          transport,
+	}
+}
+
+// Enable resumes injecting faults after Disable was called.
+func (f *FaultInjector) Enable() {
+	f.isDisabled.Store(false)
+}
+
+// Disable stops injecting faults, so that requests are sent unchanged, e.g.
+// to test how code recovers once the server is healthy again.
+func (f *FaultInjector) Disable() {
+	f.isDisabled.Store(true)
+}
+
+// GetInjectedFaultsCount returns the number of requests faults were injected
+// into so far.
+func (f *FaultInjector) GetInjectedFaultsCount() int {
+	return int(f.injectedFaultsCount.Load())
+}
+
+func (f *FaultInjector) shouldInjectFaults(request *http.Request) bool {
+	if f.isDisabled.Load() {
+		return false
+	}
+
+	if len(f.operations) > 0 {
+		isMatching := false
+		for _, operation := range f.operations {
+			if strings.HasSuffix(request.URL.Path, "/api/v1/"+string(operation)) {
+				isMatching = true
+				break
+			}
+		}
+		if !isMatching {
+			return false
+		}
+	}
+
+	if f.probability < 1 {
+		f.randomMutex.Lock()
+		isSelected := f.random.Float64() < f.probability
+		f.randomMutex.Unlock()
+
+		if !isSelected {
+			return false
+		}
+	}
+
+	f.injectedFaultsCount.Add(1)
+	return true
+}
+
+type faultInjectingTransport struct {
+	faultInjector *FaultInjector
+	next          http.RoundTripper
+}
+
+func (t *faultInjectingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	f := t.faultInjector
+	if !f.shouldInjectFaults(request) {
+		return t.next.RoundTrip(request)
+	}
+
+	if f.latency > 0 {
+		err := sleep(request.Context(), f.latency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var response *http.Response
+	if f.statusCode != 0 {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+
+		response = &http.Response{
+			Status:     fmt.Sprintf("%d %s", f.statusCode, http.StatusText(f.statusCode)),
+			StatusCode: f.statusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header: http.Header{
+				"Server":       []string{"EventSourcingDB/" + eventsourcingdb.MinimumSupportedServerVersion},
+				"Content-Type": []string{"text/plain; charset=utf-8"},
+			},
+			ContentLength: -1,
+			Body:          io.NopCloser(strings.NewReader(http.StatusText(f.statusCode) + "\n")),
+			Request:       request,
+		}
+	} else {
+		var err error
+		response, err = t.next.RoundTrip(request)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if f.serverHeader != nil {
+		if *f.serverHeader == "" {
+			response.Header.Del("Server")
+		} else {
+			response.Header.Set("Server", *f.serverHeader)
+		}
+	}
+
+	if f.resetAfterBytes >= 0 {
+		response.Body = &resettingBody{
+			body:           response.Body,
+			remainingBytes: f.resetAfterBytes,
+		}
+	}
+	if f.truncateAfterLines >= 0 {
+		response.Body = &truncatingBody{
+			body:           response.Body,
+			reader:         bufio.NewReader(response.Body),
+			remainingLines: f.truncateAfterLines,
+		}
+	}
+
+	return response, nil
+}
+
+func (t *faultInjectingTransport) CloseIdleConnections() {
+	closeIdleConnections(t.next)
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type resettingBody struct {
+	body           io.ReadCloser
+	remainingBytes int
+}
+
+func (b *resettingBody) Read(p []byte) (int, error) {
+	if b.remainingBytes == 0 {
+		return 0, ErrInjectedConnectionReset
+	}
+
+	if len(p) > b.remainingBytes {
+		p = p[:b.remainingBytes]
+	}
+
+	n, err := b.body.Read(p)
+	b.remainingBytes -= n
+
+	return n, err
+}
+
+func (b *resettingBody) Close() error {
+	return b.body.Close()
+}
+
+type truncatingBody struct {
+	body           io.ReadCloser
+	reader         *bufio.Reader
+	remainingLines int
+	pending        []byte
+	isTruncated    bool
+}
+
+func (b *truncatingBody) Read(p []byte) (int, error) {
+	if len(b.pending) == 0 {
+		if b.isTruncated {
+			return 0, io.EOF
+		}
+
+		line, err := b.reader.ReadBytes('\n')
+		if len(line) == 0 {
+			return 0, err
+		}
+
+		if b.remainingLines == 0 {
+			line = bytes.TrimSuffix(line, []byte("\n"))
+			line = line[:len(line)/2]
+			b.isTruncated = true
+
+			// Returning no bytes without an error would make callers spin.
+			if len(line) == 0 {
+				return 0, io.EOF
+			}
+		} else {
+			b.remainingLines--
+		}
+
+		b.pending = line
+	}
+
+	n := copy(p, b.pending)
+	b.pending = b.pending[n:]
+
+	return n, nil
+}
+
+func (b *truncatingBody) Close() error {
+	return b.body.Close()
+}
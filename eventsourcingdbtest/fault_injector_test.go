@@ -0,0 +1,193 @@
+package eventsourcingdbtest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+func TestFaultInjector(t *testing.T) {
+	startServerWithFaults := func(t *testing.T, faultInjector *eventsourcingdbtest.FaultInjector) *eventsourcingdb.Client {
+		t.Helper()
+
+		server := eventsourcingdbtest.NewServer().WithHeartbeatInterval(20 * time.Millisecond)
+		startServer(t, server)
+
+		client, err := server.GetClient(eventsourcingdb.WithTransport(faultInjector.Wrap))
+		require.NoError(t, err)
+
+		return client
+	}
+
+	writeEvents := func(t *testing.T, client *eventsourcingdb.Client, count int) {
+		t.Helper()
+
+		events := []eventsourcingdb.EventCandidate{}
+		for i := range count {
+			events = append(events, newEvent("/test", "io.eventsourcingdb.test", i))
+		}
+
+		_, err := client.WriteEvents(events, nil)
+		require.NoError(t, err)
+	}
+
+	t.Run("injects status codes until disabled", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().WithStatusCode(http.StatusServiceUnavailable)
+		client := startServerWithFaults(t, faultInjector)
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, 1, faultInjector.GetInjectedFaultsCount())
+
+		faultInjector.Disable()
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, faultInjector.GetInjectedFaultsCount())
+	})
+
+	t.Run("delays requests", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().WithLatency(100 * time.Millisecond)
+		client := startServerWithFaults(t, faultInjector)
+
+		startTime := time.Now()
+		err := client.Ping()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(startTime), 100*time.Millisecond)
+	})
+
+	t.Run("resets the connection after the given number of bytes", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().
+			WithConnectionResetAfter(500).
+			ForOperations(eventsourcingdb.OperationReadEvents)
+		client := startServerWithFaults(t, faultInjector)
+		writeEvents(t, client, 10)
+
+		var streamErr error
+		eventsRead := 0
+		for _, err := range client.ReadEvents(context.Background(), "/", eventsourcingdb.ReadEventsOptions{Recursive: true}) {
+			if err != nil {
+				streamErr = err
+				break
+			}
+			eventsRead++
+		}
+
+		assert.ErrorIs(t, streamErr, syscall.ECONNRESET)
+		assert.Less(t, eventsRead, 10)
+	})
+
+	t.Run("truncates streams in the middle of a line", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().
+			WithTruncatedStreamAfter(2).
+			ForOperations(eventsourcingdb.OperationObserveEvents)
+		client := startServerWithFaults(t, faultInjector)
+		writeEvents(t, client, 5)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var streamErr error
+		eventsObserved := 0
+		for _, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			if err != nil {
+				streamErr = err
+				break
+			}
+			eventsObserved++
+		}
+
+		assert.Error(t, streamErr)
+		assert.False(t, errors.Is(streamErr, context.DeadlineExceeded))
+		assert.Equal(t, 2, eventsObserved)
+	})
+
+	t.Run("ends a stream whose truncated line is empty", func(t *testing.T) {
+		transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("x\n")),
+				Request:    request,
+			}, nil
+		})
+		faultInjector := eventsourcingdbtest.NewFaultInjector().WithTruncatedStreamAfter(0)
+
+		request, err := http.NewRequest(http.MethodPost, "http://localhost:3000/api/v1/observe-events", nil)
+		require.NoError(t, err)
+		response, err := faultInjector.Wrap(transport).RoundTrip(request)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		n, err := response.Body.Read(make([]byte, 16))
+		assert.Equal(t, 0, n)
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("replaces the server header", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().WithServerHeader("nginx")
+		client := startServerWithFaults(t, faultInjector)
+
+		err := client.Ping()
+		assert.Error(t, err)
+	})
+
+	t.Run("restricts faults to the given operations", func(t *testing.T) {
+		faultInjector := eventsourcingdbtest.NewFaultInjector().
+			WithStatusCode(http.StatusServiceUnavailable).
+			ForOperations(eventsourcingdb.OperationWriteEvents)
+		client := startServerWithFaults(t, faultInjector)
+
+		err := client.VerifyAPIToken()
+		assert.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("injects faults into a reproducible fraction of requests", func(t *testing.T) {
+		countFailures := func() int {
+			faultInjector := eventsourcingdbtest.NewFaultInjector().
+				WithStatusCode(http.StatusServiceUnavailable).
+				WithProbability(0.5, 42)
+			client := startServerWithFaults(t, faultInjector)
+
+			failures := 0
+			for range 100 {
+				if client.VerifyAPIToken() != nil {
+					failures++
+				}
+			}
+			assert.Equal(t, failures, faultInjector.GetInjectedFaultsCount())
+
+			return failures
+		}
+
+		failures := countFailures()
+		assert.Greater(t, failures, 25)
+		assert.Less(t, failures, 75)
+		assert.Equal(t, failures, countFailures())
+	})
+}
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
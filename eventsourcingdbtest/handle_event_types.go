@@ -0,0 +1,64 @@
+package eventsourcingdbtest
+
+import (
+	"net/http"
+)
+
+func (s *Server) handleRegisterEventSchema(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		EventType string         `json:"eventType"`
+		Schema    map[string]any `json:"schema"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	err := s.store.RegisterEventSchema(requestBody.EventType, requestBody.Schema)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	respondWithJSON(w, map[string]any{
+		"eventType": requestBody.EventType,
+		"schema":    requestBody.Schema,
+	})
+}
+
+func (s *Server) handleReadEventType(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		EventType string `json:"eventType"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	eventType, err := s.store.ReadEventType(requestBody.EventType)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	respondWithJSON(w, eventType)
+}
+
+func (s *Server) handleReadEventTypes(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct{}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	writer := newNDJSONWriter(w)
+	for _, eventType := range s.store.ReadEventTypes() {
+		err := writer.writeLine("eventType", eventType)
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package eventsourcingdbtest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+func (s *Server) handleObserveEvents(w http.ResponseWriter, r *http.Request) {
+	type RequestBodyOptions struct {
+		Recursive       bool                   `json:"recursive"`
+		LowerBound      *store.Bound           `json:"lowerBound,omitempty"`
+		FromLatestEvent *store.FromLatestEvent `json:"fromLatestEvent,omitempty"`
+	}
+
+	type RequestBody struct {
+		Subject string             `json:"subject"`
+		Options RequestBodyOptions `json:"options"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	fromLatestEvent := requestBody.Options.FromLatestEvent
+	if fromLatestEvent != nil && fromLatestEvent.IfEventIsMissing == "read-nothing" {
+		respondWithError(w, fmt.Errorf("%w: unsupported if event is missing '%s'", store.ErrInvalidRequest, fromLatestEvent.IfEventIsMissing))
+		return
+	}
+
+	options := store.ReadEventsOptions{
+		Recursive:       requestBody.Options.Recursive,
+		LowerBound:      requestBody.Options.LowerBound,
+		FromLatestEvent: requestBody.Options.FromLatestEvent,
+	}
+
+	// The request is validated by reading once before the stream starts, so
+	// that invalid requests are answered with a proper status code.
+	changed := s.store.Watch()
+	events, err := s.store.ReadEvents(requestBody.Subject, options)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	writer := newNDJSONWriter(w)
+	heartbeat := time.NewTicker(s.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		for _, event := range events {
+			err := writer.writeLine("event", event)
+			if err != nil {
+				return
+			}
+		}
+
+		// Once events have been sent, the stream continues right after the
+		// last one, no matter how it started.
+		if len(events) > 0 {
+			options = store.ReadEventsOptions{
+				Recursive: requestBody.Options.Recursive,
+				LowerBound: &store.Bound{
+					ID:   events[len(events)-1].ID,
+					Type: "exclusive",
+				},
+			}
+			events = nil
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.stopped:
+			return
+		case <-heartbeat.C:
+			err := writer.writeLine("heartbeat", map[string]any{})
+			if err != nil {
+				return
+			}
+			continue
+		case <-changed:
+		}
+
+		changed = s.store.Watch()
+		events, err = s.store.ReadEvents(requestBody.Subject, options)
+		if err != nil {
+			_ = writer.writeLine("error", map[string]any{"error": err.Error()})
+			return
+		}
+	}
+}
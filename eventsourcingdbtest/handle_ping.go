@@ -0,0 +1,17 @@
+package eventsourcingdbtest
+
+import (
+	"net/http"
+)
+
+func (s *Server) handlePing(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, map[string]any{
+		"type": "io.eventsourcingdb.api.ping-received",
+	})
+}
+
+func (s *Server) handleVerifyAPIToken(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, map[string]any{
+		"type": "io.eventsourcingdb.api.api-token-verified",
+	})
+}
@@ -0,0 +1,40 @@
+package eventsourcingdbtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+func (s *Server) handleReadEvents(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		Subject string                  `json:"subject"`
+		Options store.ReadEventsOptions `json:"options"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	fromLatestEvent := requestBody.Options.FromLatestEvent
+	if fromLatestEvent != nil && fromLatestEvent.IfEventIsMissing == "wait-for-event" {
+		respondWithError(w, fmt.Errorf("%w: unsupported if event is missing '%s'", store.ErrInvalidRequest, fromLatestEvent.IfEventIsMissing))
+		return
+	}
+
+	events, err := s.store.ReadEvents(requestBody.Subject, requestBody.Options)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	writer := newNDJSONWriter(w)
+	for _, event := range events {
+		err := writer.writeLine("event", event)
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package eventsourcingdbtest
+
+import (
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func (s *Server) handleReadSubjects(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		BaseSubject string `json:"baseSubject"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	subjects, err := s.store.ReadSubjects(requestBody.BaseSubject)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	writer := newNDJSONWriter(w)
+	for _, subject := range subjects {
+		err := writer.writeLine("subject", internal.StreamSubject{Subject: subject})
+		if err != nil {
+			return
+		}
+	}
+}
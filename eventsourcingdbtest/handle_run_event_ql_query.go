@@ -0,0 +1,30 @@
+package eventsourcingdbtest
+
+import (
+	"net/http"
+)
+
+func (s *Server) handleRunEventQLQuery(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		Query string `json:"query"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	rows, err := s.store.RunEventQLQuery(requestBody.Query)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	writer := newNDJSONWriter(w)
+	for _, row := range rows {
+		err := writer.writeLine("row", row)
+		if err != nil {
+			return
+		}
+	}
+}
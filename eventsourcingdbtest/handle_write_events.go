@@ -0,0 +1,27 @@
+package eventsourcingdbtest
+
+import (
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+func (s *Server) handleWriteEvents(w http.ResponseWriter, r *http.Request) {
+	type RequestBody struct {
+		Events        []store.EventCandidate `json:"events"`
+		Preconditions []store.Precondition   `json:"preconditions"`
+	}
+
+	var requestBody RequestBody
+	if !parseRequestBody(w, r, &requestBody) {
+		return
+	}
+
+	writtenEvents, err := s.store.WriteEvents(requestBody.Events, requestBody.Preconditions)
+	if err != nil {
+		respondWithError(w, err)
+		return
+	}
+
+	respondWithJSON(w, writtenEvents)
+}
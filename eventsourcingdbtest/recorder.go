@@ -0,0 +1,210 @@
+package eventsourcingdbtest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const scrubbedValue = "[SCRUBBED]"
+
+// Recorder captures the requests a client sends and the responses it receives,
+// including the timing of streamed bodies, so that they can be saved as a
+// cassette. The Authorization header is always scrubbed.
+type Recorder struct {
+	mutex          sync.Mutex
+	interactions   []*Interaction
+	scrubbedValues []string
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// WithScrubbedValues replaces the given values, e.g. API tokens or personal
+// data, wherever they occur in the recorded URLs, headers, and bodies.
+func (r *Recorder) WithScrubbedValues(values ...string) *Recorder {
+	for _, value := range values {
+		if value != "" {
+			r.scrubbedValues = append(r.scrubbedValues, value)
+		}
+	}
+	return r
+}
+
+// Wrap returns a transport that records everything sent via the given
+// transport. It is meant to be used with eventsourcingdb.WithTransport.
+func (r *Recorder) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{
+		recorder: r,
+		next:     transport,
+	}
+}
+
+// GetCassette returns the interactions recorded so far. Bodies that are still
+// being read are included up to the current chunk.
+func (r *Recorder) GetCassette() *Cassette {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	cassette := &Cassette{
+		Interactions: make([]Interaction, 0, len(r.interactions)),
+	}
+	for _, interaction := range r.interactions {
+		cassette.Interactions = append(cassette.Interactions, r.scrubInteraction(*interaction))
+	}
+
+	return cassette
+}
+
+func (r *Recorder) Save(path string) error {
+	return r.GetCassette().Save(path)
+}
+
+func (r *Recorder) scrubInteraction(interaction Interaction) Interaction {
+	interaction.Request.URL = string(r.scrub([]byte(interaction.Request.URL)))
+	interaction.Request.Header = r.scrubHeader(interaction.Request.Header)
+	interaction.Request.Body = r.scrub(interaction.Request.Body)
+	if interaction.Request.Header.Get("Authorization") != "" {
+		interaction.Request.Header.Set("Authorization", scrubbedValue)
+	}
+
+	interaction.Response.Header = r.scrubHeader(interaction.Response.Header)
+	interaction.Response.Chunks = slices.Clone(interaction.Response.Chunks)
+	for i, chunk := range interaction.Response.Chunks {
+		interaction.Response.Chunks[i].Data = r.scrub(chunk.Data)
+	}
+
+	return interaction
+}
+
+func (r *Recorder) scrubHeader(header http.Header) http.Header {
+	scrubbedHeader := header.Clone()
+	for _, values := range scrubbedHeader {
+		for i, value := range values {
+			values[i] = string(r.scrub([]byte(value)))
+		}
+	}
+
+	return scrubbedHeader
+}
+
+func (r *Recorder) scrub(data []byte) []byte {
+	for _, value := range r.scrubbedValues {
+		data = bytes.ReplaceAll(data, []byte(value), []byte(scrubbedValue))
+	}
+
+	return data
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, outgoingRequest, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	response, err := t.next.RoundTrip(outgoingRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: request.Method,
+			URL:    request.URL.RequestURI(),
+			Header: request.Header.Clone(),
+			Body:   requestBody,
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Header:     response.Header.Clone(),
+			Duration:   time.Since(startTime),
+			Chunks:     []RecordedChunk{},
+		},
+	}
+
+	t.recorder.mutex.Lock()
+	t.recorder.interactions = append(t.recorder.interactions, interaction)
+	t.recorder.mutex.Unlock()
+
+	response.Body = &recordingBody{
+		recorder:    t.recorder,
+		interaction: interaction,
+		body:        response.Body,
+		startTime:   time.Now(),
+	}
+
+	return response, nil
+}
+
+func (t *recordingTransport) CloseIdleConnections() {
+	closeIdleConnections(t.next)
+}
+
+// readRequestBody reads the body of the given request, and returns a clone of
+// the request that can still be sent, since a RoundTripper must not modify the
+// request it was given.
+func readRequestBody(request *http.Request) ([]byte, *http.Request, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, request, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clonedRequest := request.Clone(request.Context())
+	clonedRequest.Body = io.NopCloser(bytes.NewReader(body))
+	clonedRequest.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, clonedRequest, nil
+}
+
+func closeIdleConnections(transport http.RoundTripper) {
+	if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
+
+type recordingBody struct {
+	recorder    *Recorder
+	interaction *Interaction
+	body        io.ReadCloser
+	startTime   time.Time
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+
+	b.recorder.mutex.Lock()
+	defer b.recorder.mutex.Unlock()
+
+	if n > 0 {
+		b.interaction.Response.Chunks = append(b.interaction.Response.Chunks, RecordedChunk{
+			Offset: time.Since(b.startTime),
+			Data:   bytes.Clone(p[:n]),
+		})
+	}
+	if err == io.EOF {
+		b.interaction.Response.IsComplete = true
+	}
+
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,248 @@
+package eventsourcingdbtest_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	observeEvents := func(t *testing.T, client *eventsourcingdb.Client, count int) []eventsourcingdb.Event {
+		t.Helper()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		events := []eventsourcingdb.Event{}
+		for event, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+			events = append(events, event)
+
+			if len(events) == count {
+				break
+			}
+		}
+
+		return events
+	}
+
+	record := func(t *testing.T, run func(client *eventsourcingdb.Client)) string {
+		t.Helper()
+
+		server := eventsourcingdbtest.NewServer().WithHeartbeatInterval(20 * time.Millisecond)
+		err := server.Start()
+		require.NoError(t, err)
+		defer server.Stop()
+
+		recorder := eventsourcingdbtest.NewRecorder()
+		client, err := server.GetClient(eventsourcingdb.WithTransport(recorder.Wrap))
+		require.NoError(t, err)
+
+		run(client)
+
+		cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+		err = recorder.Save(cassettePath)
+		require.NoError(t, err)
+
+		return cassettePath
+	}
+
+	replay := func(t *testing.T, cassettePath string, configure func(replayer *eventsourcingdbtest.Replayer)) (*eventsourcingdb.Client, *eventsourcingdbtest.Replayer) {
+		t.Helper()
+
+		cassette, err := eventsourcingdbtest.LoadCassette(cassettePath)
+		require.NoError(t, err)
+
+		replayer := eventsourcingdbtest.NewReplayer(cassette)
+		if configure != nil {
+			configure(replayer)
+		}
+
+		client, err := eventsourcingdb.NewClient(
+			&url.URL{Scheme: "http", Host: "localhost:3000"},
+			"secret",
+			eventsourcingdb.WithTransport(replayer.Wrap),
+		)
+		require.NoError(t, err)
+
+		return client, replayer
+	}
+
+	t.Run("replays recorded requests without a server", func(t *testing.T) {
+		var recordedEvents []eventsourcingdb.Event
+		var recordedSubjects []string
+
+		cassettePath := record(t, func(client *eventsourcingdb.Client) {
+			_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEvent("/test", "io.eventsourcingdb.test", 23),
+				newEvent("/test", "io.eventsourcingdb.test", 42),
+			}, nil)
+			require.NoError(t, err)
+
+			recordedEvents = readEvents(t, client, "/", eventsourcingdb.ReadEventsOptions{Recursive: true})
+
+			for subject, err := range client.ReadSubjects(context.Background(), "/") {
+				require.NoError(t, err)
+				recordedSubjects = append(recordedSubjects, subject)
+			}
+		})
+
+		client, replayer := replay(t, cassettePath, nil)
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+			newEvent("/test", "io.eventsourcingdb.test", 42),
+		}, nil)
+		require.NoError(t, err)
+
+		events := readEvents(t, client, "/", eventsourcingdb.ReadEventsOptions{Recursive: true})
+		assert.Equal(t, recordedEvents, events)
+		for _, event := range events {
+			assert.NoError(t, event.VerifyHash())
+		}
+
+		subjects := []string{}
+		for subject, err := range client.ReadSubjects(context.Background(), "/") {
+			require.NoError(t, err)
+			subjects = append(subjects, subject)
+		}
+		assert.Equal(t, recordedSubjects, subjects)
+
+		assert.True(t, replayer.HasServedAllInteractions())
+	})
+
+	t.Run("scrubs the API token and the given values", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer().WithAPIToken("super-secret-token")
+		startServer(t, server)
+
+		recorder := eventsourcingdbtest.NewRecorder().WithScrubbedValues("personal-data")
+		client, err := server.GetClient(eventsourcingdb.WithTransport(recorder.Wrap))
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/personal-data", "io.eventsourcingdb.test", 23),
+		}, nil)
+		require.NoError(t, err)
+
+		cassettePath := filepath.Join(t.TempDir(), "cassette.json")
+		err = recorder.Save(cassettePath)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(cassettePath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), "super-secret-token")
+		assert.NotContains(t, string(content), "personal-data")
+		assert.Contains(t, string(content), "[SCRUBBED]")
+	})
+
+	t.Run("replays streams with their timing", func(t *testing.T) {
+		cassettePath := record(t, func(client *eventsourcingdb.Client) {
+			_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEvent("/test", "io.eventsourcingdb.test", 23),
+			}, nil)
+			require.NoError(t, err)
+
+			time.AfterFunc(200*time.Millisecond, func() {
+				client.WriteEvents([]eventsourcingdb.EventCandidate{
+					newEvent("/test", "io.eventsourcingdb.test", 42),
+				}, nil)
+			})
+
+			events := observeEvents(t, client, 2)
+			require.Len(t, events, 2)
+		})
+
+		client, _ := replay(t, cassettePath, nil)
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		require.NoError(t, err)
+
+		startTime := time.Now()
+		events := observeEvents(t, client, 2)
+		assert.Equal(t, []int{23, 42}, getValues(t, events))
+		assert.GreaterOrEqual(t, time.Since(startTime), 150*time.Millisecond)
+
+		fastClient, _ := replay(t, cassettePath, func(replayer *eventsourcingdbtest.Replayer) {
+			replayer.WithoutDelays()
+		})
+
+		_, err = fastClient.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		require.NoError(t, err)
+
+		startTime = time.Now()
+		events = observeEvents(t, fastClient, 2)
+		assert.Equal(t, []int{23, 42}, getValues(t, events))
+		assert.Less(t, time.Since(startTime), 150*time.Millisecond)
+	})
+
+	t.Run("keeps an aborted stream open until the request is cancelled", func(t *testing.T) {
+		cassettePath := record(t, func(client *eventsourcingdb.Client) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			for _, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+				require.NoError(t, err)
+			}
+		})
+
+		client, _ := replay(t, cassettePath, func(replayer *eventsourcingdbtest.Replayer) {
+			replayer.WithoutDelays()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(100*time.Millisecond, cancel)
+
+		startTime := time.Now()
+		for _, err := range client.ObserveEvents(ctx, "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			require.NoError(t, err)
+		}
+		assert.GreaterOrEqual(t, time.Since(startTime), 100*time.Millisecond)
+	})
+
+	t.Run("fails for requests that have not been recorded", func(t *testing.T) {
+		cassettePath := record(t, func(client *eventsourcingdb.Client) {
+			err := client.VerifyAPIToken()
+			require.NoError(t, err)
+		})
+
+		client, replayer := replay(t, cassettePath, nil)
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		assert.ErrorIs(t, err, eventsourcingdbtest.ErrNoRecordedInteraction)
+		assert.False(t, replayer.HasServedAllInteractions())
+	})
+
+	t.Run("passes requests through to the wrapped transport", func(t *testing.T) {
+		recorder := eventsourcingdbtest.NewRecorder()
+		transport := recorder.Wrap(http.DefaultTransport)
+
+		server := eventsourcingdbtest.NewServer()
+		startServer(t, server)
+
+		baseURL, err := server.GetBaseURL()
+		require.NoError(t, err)
+
+		response, err := (&http.Client{Transport: transport}).Get(baseURL.JoinPath("api", "v1", "ping").String())
+		require.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		require.Len(t, recorder.GetCassette().Interactions, 1)
+		assert.Equal(t, "/api/v1/ping", recorder.GetCassette().Interactions[0].Request.URL)
+	})
+}
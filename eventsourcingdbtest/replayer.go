@@ -0,0 +1,184 @@
+package eventsourcingdbtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var ErrNoRecordedInteraction = errors.New("no recorded interaction matches the request")
+
+// Replayer serves the interactions of a cassette instead of sending requests to
+// a server. A request matches an interaction if its method, path, query, and
+// body are equal, and every interaction is served only once, in the order it
+// was recorded. By default, the recorded timing is reproduced.
+type Replayer struct {
+	cassette      *Cassette
+	mutex         sync.Mutex
+	isServed      []bool
+	withoutDelays bool
+}
+
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{
+		cassette: cassette,
+		isServed: make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// WithoutDelays serves responses and their chunks right away, instead of
+// reproducing the recorded timing.
+func (r *Replayer) WithoutDelays() *Replayer {
+	r.withoutDelays = true
+	return r
+}
+
+// Wrap returns the replayer itself, and ignores the given transport, since no
+// requests are sent. It is meant to be used with eventsourcingdb.WithTransport.
+func (r *Replayer) Wrap(transport http.RoundTripper) http.RoundTripper {
+	return r
+}
+
+// HasServedAllInteractions reports whether every interaction of the cassette
+// has been served.
+func (r *Replayer) HasServedAllInteractions() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, isServed := range r.isServed {
+		if !isServed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, _, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction, ok := r.takeInteraction(request.Method, request.URL.RequestURI(), requestBody)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecordedInteraction, request.Method, request.URL.RequestURI())
+	}
+
+	ctx := request.Context()
+	err = r.wait(ctx, time.Now().Add(interaction.Response.Duration))
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header.Clone(),
+		ContentLength: -1,
+		Request:       request,
+		Body: &replayingBody{
+			replayer:  r,
+			ctx:       ctx,
+			response:  interaction.Response,
+			startTime: time.Now(),
+			closed:    make(chan struct{}),
+		},
+	}, nil
+}
+
+func (r *Replayer) takeInteraction(method string, url string, body []byte) (Interaction, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.isServed[i] {
+			continue
+		}
+		if interaction.Request.Method != method ||
+			interaction.Request.URL != url ||
+			!bytes.Equal(interaction.Request.Body, body) {
+			continue
+		}
+
+		r.isServed[i] = true
+		return interaction, true
+	}
+
+	return Interaction{}, false
+}
+
+func (r *Replayer) wait(ctx context.Context, until time.Time) error {
+	if r.withoutDelays {
+		return ctx.Err()
+	}
+
+	return sleep(ctx, time.Until(until))
+}
+
+var errBodyClosed = errors.New("body is closed")
+
+type replayingBody struct {
+	replayer  *Replayer
+	ctx       context.Context
+	response  RecordedResponse
+	startTime time.Time
+	nextChunk int
+	pending   []byte
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (b *replayingBody) Read(p []byte) (int, error) {
+	select {
+	case <-b.closed:
+		return 0, errBodyClosed
+	default:
+	}
+
+	if len(b.pending) == 0 {
+		if b.nextChunk == len(b.response.Chunks) {
+			if b.response.IsComplete {
+				return 0, io.EOF
+			}
+
+			// The recorded stream was still open when it was aborted, so it
+			// stays open until the request is cancelled.
+			select {
+			case <-b.ctx.Done():
+				return 0, b.ctx.Err()
+			case <-b.closed:
+				return 0, errBodyClosed
+			}
+		}
+
+		chunk := b.response.Chunks[b.nextChunk]
+		err := b.replayer.wait(b.ctx, b.startTime.Add(chunk.Offset))
+		if err != nil {
+			return 0, err
+		}
+
+		b.pending = chunk.Data
+		b.nextChunk++
+	}
+
+	n := copy(p, b.pending)
+	b.pending = b.pending[n:]
+
+	return n, nil
+}
+
+func (b *replayingBody) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+	return nil
+}
@@ -0,0 +1,102 @@
+package eventsourcingdbtest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+func (s *Server) authenticate(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.apiToken {
+			http.Error(w, "invalid API token", http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func parseRequestBody(w http.ResponseWriter, r *http.Request, requestBody any) bool {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return false
+		}
+		defer reader.Close()
+		body = reader
+	}
+
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(requestBody)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+func respondWithError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, store.ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, store.ErrPreconditionFailed), errors.Is(err, store.ErrConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, store.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, store.ErrNotSupported):
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func respondWithJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	_ = internal.NewJSONEncoder(w).Encode(value)
+}
+
+// ndjsonWriter writes the lines of an NDJSON stream, and flushes them right
+// away, so that the client receives them without delay.
+type ndjsonWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w http.ResponseWriter) *ndjsonWriter {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	encoder := internal.NewJSONEncoder(w)
+
+	return &ndjsonWriter{
+		w:       w,
+		encoder: encoder,
+	}
+}
+
+func (n *ndjsonWriter) writeLine(lineType string, payload any) error {
+	err := n.encoder.Encode(map[string]any{
+		"type":    lineType,
+		"payload": payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flusher, ok := n.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
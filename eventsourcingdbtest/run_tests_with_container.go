@@ -0,0 +1,37 @@
+package eventsourcingdbtest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+// RunTestsWithContainer starts the given container, runs the tests of a
+// package, and stops the container afterwards. It is meant to be called from
+// TestMain, so that all tests of a package share a single container, and it
+// returns the exit code to pass to os.Exit. Tests that need a clean state can
+// call Reset on the container.
+func RunTestsWithContainer(m *testing.M, container *eventsourcingdb.Container) int {
+	ctx := context.Background()
+
+	err := container.Start(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start container: %v\n", err)
+		return 1
+	}
+
+	exitCode := m.Run()
+
+	err = container.Stop(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to stop container: %v\n", err)
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	}
+
+	return exitCode
+}
@@ -0,0 +1,135 @@
+package eventsourcingdbtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+)
+
+// Command is the code under test in a scenario, e.g. a command handler that
+// writes events to the given event store.
+type Command func(eventStore eventsourcingdb.EventStore) error
+
+// Scenario tests event-sourced logic in the form Given(events...).When(command)
+// followed by ThenExpect or ThenExpectError. Only the events written by the
+// command are checked, so a scenario can run against a store that already
+// contains events, e.g. a shared container.
+type Scenario struct {
+	t            testing.TB
+	eventStore   eventsourcingdb.EventStore
+	lastEventID  string
+	hasRun       bool
+	commandError error
+}
+
+// Given writes the given events to the event store, as the history the
+// command runs against.
+func Given(t testing.TB, eventStore eventsourcingdb.EventStore, events ...eventsourcingdb.EventCandidate) *Scenario {
+	t.Helper()
+
+	if len(events) > 0 {
+		_, err := eventStore.WriteEvents(events, nil)
+		if err != nil {
+			t.Fatalf("failed to write given events: %v", err)
+		}
+	}
+
+	return &Scenario{
+		t:          t,
+		eventStore: eventStore,
+	}
+}
+
+func (s *Scenario) When(command Command) *Scenario {
+	s.t.Helper()
+
+	lastEventID, err := getLastEventID(s.t, s.eventStore)
+	if err != nil {
+		s.t.Fatalf("failed to read the last event: %v", err)
+	}
+
+	s.lastEventID = lastEventID
+	s.commandError = command(s.eventStore)
+	s.hasRun = true
+
+	return s
+}
+
+// ThenExpect checks that the command succeeded and wrote exactly the given
+// events, in order. Server-generated fields such as the ID, time, and hash
+// are ignored.
+func (s *Scenario) ThenExpect(events ...eventsourcingdb.EventCandidate) {
+	s.t.Helper()
+
+	if !s.hasRun {
+		s.t.Fatalf("When must be called before ThenExpect")
+	}
+	if s.commandError != nil {
+		s.t.Errorf("expected command to succeed, but it failed: %v", s.commandError)
+		return
+	}
+
+	compareEvents(s.t, "written events", s.readWrittenEvents(), events)
+}
+
+// ThenExpectError checks that the command failed without writing events. If
+// target is not nil, the error must match it according to errors.Is.
+func (s *Scenario) ThenExpectError(target error) {
+	s.t.Helper()
+
+	if !s.hasRun {
+		s.t.Fatalf("When must be called before ThenExpectError")
+	}
+	if s.commandError == nil {
+		s.t.Errorf("expected command to fail, but it succeeded")
+	} else if target != nil && !errors.Is(s.commandError, target) {
+		s.t.Errorf("expected command to fail with %v, but it failed with %v", target, s.commandError)
+	}
+
+	writtenEvents := s.readWrittenEvents()
+	if len(writtenEvents) > 0 {
+		s.t.Errorf("expected command to write no events, but it wrote %d", len(writtenEvents))
+	}
+}
+
+func (s *Scenario) readWrittenEvents() []eventsourcingdb.Event {
+	s.t.Helper()
+
+	options := eventsourcingdb.ReadEventsOptions{
+		Recursive: true,
+	}
+	if s.lastEventID != "" {
+		options.LowerBound = &eventsourcingdb.Bound{
+			ID:   s.lastEventID,
+			Type: eventsourcingdb.BoundTypeExclusive,
+		}
+	}
+
+	events := []eventsourcingdb.Event{}
+	for event, err := range s.eventStore.ReadEvents(s.t.Context(), "/", options) {
+		if err != nil {
+			s.t.Fatalf("failed to read written events: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func getLastEventID(t testing.TB, eventStore eventsourcingdb.EventStore) (string, error) {
+	options := eventsourcingdb.ReadEventsOptions{
+		Recursive: true,
+		Order:     eventsourcingdb.OrderAntichronological(),
+	}
+
+	for event, err := range eventStore.ReadEvents(t.Context(), "/", options) {
+		if err != nil {
+			return "", err
+		}
+
+		return event.ID, nil
+	}
+
+	return "", nil
+}
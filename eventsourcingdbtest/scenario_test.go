@@ -0,0 +1,206 @@
+package eventsourcingdbtest_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+// recordingT records the failures of helpers under test instead of failing
+// the actual test.
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func (t *recordingT) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func getFailures(t *testing.T, run func(t testing.TB)) []string {
+	t.Helper()
+
+	recordingT := &recordingT{TB: t}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(recordingT)
+	}()
+	<-done
+
+	return recordingT.failures
+}
+
+var errBookAlreadyBorrowed = errors.New("book already borrowed")
+
+func borrowBook(eventStore eventsourcingdb.EventStore) error {
+	_, err := eventStore.WriteEvents(
+		[]eventsourcingdb.EventCandidate{newEvent("/books/42", "io.eventsourcingdb.library.book-borrowed", 1)},
+		[]eventsourcingdb.Precondition{
+			eventsourcingdb.NewIsSubjectOnEventIDPrecondition("/books/42", "0"),
+		},
+	)
+	if err != nil {
+		return errBookAlreadyBorrowed
+	}
+
+	return nil
+}
+
+func TestScenario(t *testing.T) {
+	bookAcquired := newEvent("/books/42", "io.eventsourcingdb.library.book-acquired", 0)
+	bookBorrowed := newEvent("/books/42", "io.eventsourcingdb.library.book-borrowed", 1)
+
+	t.Run("passes if the command writes the expected events", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client, bookAcquired).
+				When(borrowBook).
+				ThenExpect(bookBorrowed)
+		})
+		assert.Empty(t, failures)
+	})
+
+	t.Run("fails if the command writes other events", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client, bookAcquired).
+				When(borrowBook).
+				ThenExpect(newEvent("/books/42", "io.eventsourcingdb.library.book-borrowed", 2))
+		})
+		require.Len(t, failures, 1)
+		assert.Contains(t, failures[0], `expected data {"value":2}, got {"value":1}`)
+	})
+
+	t.Run("fails if the command fails unexpectedly", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client).
+				When(borrowBook).
+				ThenExpect(bookBorrowed)
+		})
+		require.Len(t, failures, 1)
+		assert.Contains(t, failures[0], "book already borrowed")
+	})
+
+	t.Run("passes if the command fails as expected", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client, bookAcquired, bookBorrowed).
+				When(borrowBook).
+				ThenExpectError(errBookAlreadyBorrowed)
+		})
+		assert.Empty(t, failures)
+	})
+
+	t.Run("fails if the command succeeds although an error is expected", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client, bookAcquired).
+				When(borrowBook).
+				ThenExpectError(nil)
+		})
+		assert.Equal(t, []string{
+			"expected command to fail, but it succeeded",
+			"expected command to write no events, but it wrote 1",
+		}, failures)
+	})
+
+	t.Run("only checks the events written by the command", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/books/23", "io.eventsourcingdb.library.book-acquired", 0),
+		}, nil)
+		require.NoError(t, err)
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.Given(t, client).
+				When(func(eventStore eventsourcingdb.EventStore) error {
+					_, err := eventStore.WriteEvents([]eventsourcingdb.EventCandidate{bookAcquired}, nil)
+					return err
+				}).
+				ThenExpect(bookAcquired)
+		})
+		assert.Empty(t, failures)
+	})
+}
+
+func TestAssertions(t *testing.T) {
+	t.Run("asserts the events of a subject", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/books/42", "io.eventsourcingdb.library.book-acquired", 23),
+			newEvent("/books/42/pages", "io.eventsourcingdb.library.page-added", 1),
+			newEvent("/books/42", "io.eventsourcingdb.library.book-borrowed", 42),
+		}, nil)
+		require.NoError(t, err)
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.AssertSubjectHasEvents(t, client, "/books/42",
+				newEvent("/books/42", "io.eventsourcingdb.library.book-acquired", 23),
+				newEvent("/books/42", "io.eventsourcingdb.library.book-borrowed", 42),
+			)
+			eventsourcingdbtest.AssertEventTypes(t, client, "/books/42",
+				"io.eventsourcingdb.library.book-acquired",
+				"io.eventsourcingdb.library.book-borrowed",
+			)
+		})
+		assert.Empty(t, failures)
+
+		failures = getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.AssertSubjectHasEvents(t, client, "/books/42",
+				newEvent("/books/42", "io.eventsourcingdb.library.book-acquired", 23),
+			)
+			eventsourcingdbtest.AssertEventTypes(t, client, "/books/42",
+				"io.eventsourcingdb.library.book-borrowed",
+			)
+		})
+		assert.Equal(t, []string{
+			"expected 1 events of /books/42, got 2",
+			"expected event types of /books/42 to be [io.eventsourcingdb.library.book-borrowed], got [io.eventsourcingdb.library.book-acquired io.eventsourcingdb.library.book-borrowed]",
+		}, failures)
+	})
+
+	t.Run("asserts data regardless of key order", func(t *testing.T) {
+		event := eventsourcingdb.Event{
+			ID:   "0",
+			Data: []byte(`{"title":"2001","author":"Arthur C. Clarke"}`),
+		}
+
+		failures := getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.AssertDataEquals(t, event, map[string]any{
+				"author": "Arthur C. Clarke",
+				"title":  "2001",
+			})
+		})
+		assert.Empty(t, failures)
+
+		failures = getFailures(t, func(t testing.TB) {
+			eventsourcingdbtest.AssertDataEquals(t, event, map[string]any{
+				"title": "2010",
+			})
+		})
+		assert.Equal(t, []string{
+			`expected data of event 0 to be {"title":"2010"}, got {"title":"2001","author":"Arthur C. Clarke"}`,
+		}, failures)
+	})
+}
@@ -0,0 +1,186 @@
+package eventsourcingdbtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal/store"
+)
+
+// EventQLEvaluator evaluates an EventQL query against the given events, and
+// returns the resulting rows. Each row must be serializable to JSON. For the
+// isEventQLQueryTrue precondition, the first row must be true.
+type EventQLEvaluator func(query string, events []eventsourcingdb.Event) ([]any, error)
+
+type Server struct {
+	apiToken          string
+	signingKey        *ed25519.PrivateKey
+	eventQLEvaluator  EventQLEvaluator
+	heartbeatInterval time.Duration
+	store             *store.Store
+	server            *httptest.Server
+	stopped           chan struct{}
+}
+
+func NewServer() *Server {
+	return &Server{
+		apiToken:          "secret",
+		signingKey:        nil,
+		heartbeatInterval: time.Second,
+	}
+}
+
+func (s *Server) WithAPIToken(token string) *Server {
+	s.apiToken = token
+	return s
+}
+
+func (s *Server) WithSigningKey() *Server {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	s.signingKey = &privateKey
+	return s
+}
+
+func (s *Server) WithEventQLEvaluator(evaluator EventQLEvaluator) *Server {
+	s.eventQLEvaluator = evaluator
+	return s
+}
+
+func (s *Server) WithHeartbeatInterval(interval time.Duration) *Server {
+	s.heartbeatInterval = interval
+	return s
+}
+
+func (s *Server) Start() error {
+	if s.server != nil {
+		return errors.New("server is already running")
+	}
+
+	s.store = store.New()
+	if s.signingKey != nil {
+		s.store.SetSigningKey(*s.signingKey)
+	}
+	if s.eventQLEvaluator != nil {
+		s.store.SetQueryEvaluator(s.evaluateQuery)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/ping", s.handlePing)
+	mux.HandleFunc("POST /api/v1/verify-api-token", s.authenticate(s.handleVerifyAPIToken))
+	mux.HandleFunc("POST /api/v1/write-events", s.authenticate(s.handleWriteEvents))
+	mux.HandleFunc("POST /api/v1/read-events", s.authenticate(s.handleReadEvents))
+	mux.HandleFunc("POST /api/v1/observe-events", s.authenticate(s.handleObserveEvents))
+	mux.HandleFunc("POST /api/v1/read-subjects", s.authenticate(s.handleReadSubjects))
+	mux.HandleFunc("POST /api/v1/register-event-schema", s.authenticate(s.handleRegisterEventSchema))
+	mux.HandleFunc("POST /api/v1/read-event-type", s.authenticate(s.handleReadEventType))
+	mux.HandleFunc("POST /api/v1/read-event-types", s.authenticate(s.handleReadEventTypes))
+	mux.HandleFunc("POST /api/v1/run-eventql-query", s.authenticate(s.handleRunEventQLQuery))
+
+	s.stopped = make(chan struct{})
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "EventSourcingDB/"+eventsourcingdb.MinimumSupportedServerVersion)
+		mux.ServeHTTP(w, r)
+	}))
+
+	return nil
+}
+
+func (s *Server) GetBaseURL() (*url.URL, error) {
+	if s.server == nil {
+		return nil, errors.New("server must be running")
+	}
+
+	return url.Parse(s.server.URL)
+}
+
+func (s *Server) GetAPIToken() string {
+	return s.apiToken
+}
+
+func (s *Server) GetSigningKey() (*ed25519.PrivateKey, error) {
+	if s.signingKey == nil {
+		return nil, errors.New("signing key not set")
+	}
+
+	return s.signingKey, nil
+}
+
+func (s *Server) GetVerificationKey() (*ed25519.PublicKey, error) {
+	if s.signingKey == nil {
+		return nil, errors.New("signing key not set")
+	}
+
+	verificationKey, ok := s.signingKey.Public().(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("failed to get verification key from signing key")
+	}
+
+	return &verificationKey, nil
+}
+
+func (s *Server) IsRunning() bool {
+	return s.server != nil
+}
+
+// Stop shuts down the server and discards all of its data. Running streams,
+// such as the ones of ObserveEvents, are terminated.
+func (s *Server) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+
+	close(s.stopped)
+	s.server.CloseClientConnections()
+	s.server.Close()
+
+	s.server = nil
+	s.store = nil
+	return nil
+}
+
+func (s *Server) GetClient(options ...eventsourcingdb.ClientOption) (*eventsourcingdb.Client, error) {
+	baseURL, err := s.GetBaseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return eventsourcingdb.NewClient(baseURL, s.apiToken, options...)
+}
+
+func (s *Server) evaluateQuery(query string, cloudEvents []internal.CloudEvent) ([]json.RawMessage, error) {
+	events := make([]eventsourcingdb.Event, 0, len(cloudEvents))
+	for _, cloudEvent := range cloudEvents {
+		event, err := eventsourcingdb.NewEventFromCloudEvent(cloudEvent)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	rows, err := s.eventQLEvaluator(query, events)
+	if err != nil {
+		return nil, err
+	}
+
+	rawRows := make([]json.RawMessage, 0, len(rows))
+	for _, row := range rows {
+		rawRow, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		rawRows = append(rawRows, rawRow)
+	}
+
+	return rawRows, nil
+}
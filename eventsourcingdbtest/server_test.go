@@ -0,0 +1,479 @@
+package eventsourcingdbtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdb"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+type EventData struct {
+	Value int `json:"value"`
+}
+
+func startServer(t *testing.T, server *eventsourcingdbtest.Server) *eventsourcingdb.Client {
+	t.Helper()
+
+	err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		server.Stop()
+	})
+
+	client, err := server.GetClient()
+	require.NoError(t, err)
+
+	return client
+}
+
+func newEvent(subject string, eventType string, value int) eventsourcingdb.EventCandidate {
+	return eventsourcingdb.EventCandidate{
+		Source:  "https://www.eventsourcingdb.io",
+		Subject: subject,
+		Type:    eventType,
+		Data: EventData{
+			Value: value,
+		},
+	}
+}
+
+func getValues(t *testing.T, events []eventsourcingdb.Event) []int {
+	t.Helper()
+
+	values := []int{}
+	for _, event := range events {
+		var data EventData
+		err := json.Unmarshal(event.Data, &data)
+		require.NoError(t, err)
+		values = append(values, data.Value)
+	}
+
+	return values
+}
+
+func readEvents(t *testing.T, client *eventsourcingdb.Client, subject string, options eventsourcingdb.ReadEventsOptions) []eventsourcingdb.Event {
+	t.Helper()
+
+	events := []eventsourcingdb.Event{}
+	for event, err := range client.ReadEvents(context.Background(), subject, options) {
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func TestServer(t *testing.T) {
+	t.Run("pings and verifies the API token", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer()
+		client := startServer(t, server)
+
+		err := client.Ping()
+		assert.NoError(t, err)
+
+		err = client.VerifyAPIToken()
+		assert.NoError(t, err)
+
+		baseURL, err := server.GetBaseURL()
+		require.NoError(t, err)
+
+		invalidClient, err := eventsourcingdb.NewClient(baseURL, "invalid")
+		require.NoError(t, err)
+
+		err = invalidClient.VerifyAPIToken()
+		assert.Error(t, err)
+	})
+
+	t.Run("writes events with a valid hash chain", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		firstEvents, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+			newEvent("/test", "io.eventsourcingdb.test", 42),
+		}, nil)
+		require.NoError(t, err)
+		secondEvents, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 7),
+		}, nil)
+		require.NoError(t, err)
+
+		writtenEvents := append(firstEvents, secondEvents...)
+		assert.Equal(t, []int{23, 42, 7}, getValues(t, writtenEvents))
+
+		readEvents := readEvents(t, client, "/test", eventsourcingdb.ReadEventsOptions{})
+		assert.Equal(t, writtenEvents, readEvents)
+
+		for i, event := range readEvents {
+			assert.Equal(t, []string{"0", "1", "2"}[i], event.ID)
+			assert.NoError(t, event.VerifyHash())
+			if i > 0 {
+				assert.Equal(t, readEvents[i-1].Hash, event.PredecessorHash)
+			}
+		}
+	})
+
+	t.Run("signs events if a signing key is set", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer().WithSigningKey()
+		client := startServer(t, server)
+
+		writtenEvents, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		require.NoError(t, err)
+
+		verificationKey, err := server.GetVerificationKey()
+		require.NoError(t, err)
+
+		err = writtenEvents[0].VerifySignature(*verificationKey)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects invalid events", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		for _, event := range []eventsourcingdb.EventCandidate{
+			newEvent("test", "io.eventsourcingdb.test", 23),
+			newEvent("/test", "io.eventsourcingdb.test.", 23),
+			{Source: "https://www.eventsourcingdb.io", Subject: "/test", Type: "io.eventsourcingdb.test", Data: 23},
+		} {
+			_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{event}, nil)
+			assert.EqualError(t, err, "failed to write events, got HTTP status code '400', expected '200'")
+		}
+	})
+
+	t.Run("supports the subject preconditions", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		writtenEvents, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, []eventsourcingdb.Precondition{
+			eventsourcingdb.NewIsSubjectPristinePrecondition("/test"),
+		})
+		require.NoError(t, err)
+
+		for _, precondition := range []eventsourcingdb.Precondition{
+			eventsourcingdb.NewIsSubjectPristinePrecondition("/test"),
+			eventsourcingdb.NewIsSubjectPopulatedPrecondition("/other"),
+			eventsourcingdb.NewIsSubjectOnEventIDPrecondition("/test", "1"),
+		} {
+			_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEvent("/test", "io.eventsourcingdb.test", 42),
+			}, []eventsourcingdb.Precondition{precondition})
+			assert.EqualError(t, err, "failed to write events, got HTTP status code '409', expected '200'")
+		}
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 42),
+		}, []eventsourcingdb.Precondition{
+			eventsourcingdb.NewIsSubjectPopulatedPrecondition("/test"),
+			eventsourcingdb.NewIsSubjectOnEventIDPrecondition("/test", writtenEvents[0].ID),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("evaluates EventQL using the given evaluator", func(t *testing.T) {
+		countEvents := func(query string, events []eventsourcingdb.Event) ([]any, error) {
+			switch query {
+			case "FROM e IN events PROJECT INTO COUNT() == 0":
+				return []any{len(events) == 0}, nil
+			case "FROM e IN events PROJECT INTO e":
+				rows := []any{}
+				for _, event := range events {
+					rows = append(rows, event)
+				}
+				return rows, nil
+			default:
+				return nil, errors.New("unsupported query")
+			}
+		}
+
+		client := startServer(t, eventsourcingdbtest.NewServer().WithEventQLEvaluator(countEvents))
+
+		precondition := eventsourcingdb.NewIsEventQLQueryTruePrecondition("FROM e IN events PROJECT INTO COUNT() == 0")
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, []eventsourcingdb.Precondition{precondition})
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 42),
+		}, []eventsourcingdb.Precondition{precondition})
+		assert.EqualError(t, err, "failed to write events, got HTTP status code '409', expected '200'")
+
+		rows := []eventsourcingdb.Event{}
+		for row, err := range client.RunEventQLQuery(context.Background(), "FROM e IN events PROJECT INTO e") {
+			require.NoError(t, err)
+
+			var event eventsourcingdb.Event
+			err = json.Unmarshal(row, &event)
+			require.NoError(t, err)
+			rows = append(rows, event)
+		}
+		assert.Equal(t, []int{23}, getValues(t, rows))
+	})
+
+	t.Run("fails to run EventQL queries without an evaluator", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		for _, err := range client.RunEventQLQuery(context.Background(), "FROM e IN events PROJECT INTO e") {
+			assert.EqualError(t, err, "failed to run EventQL query, got HTTP status code '501', expected '200'")
+		}
+	})
+
+	t.Run("reads events with options", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test.foo", 1),
+			newEvent("/test/nested", "io.eventsourcingdb.test.foo", 2),
+			newEvent("/test", "io.eventsourcingdb.test.bar", 3),
+			newEvent("/test", "io.eventsourcingdb.test.foo", 4),
+			newEvent("/other", "io.eventsourcingdb.test.foo", 5),
+		}, nil)
+		require.NoError(t, err)
+
+		testCases := []struct {
+			name     string
+			subject  string
+			options  eventsourcingdb.ReadEventsOptions
+			expected []int
+		}{
+			{"non-recursively", "/test", eventsourcingdb.ReadEventsOptions{}, []int{1, 3, 4}},
+			{"recursively", "/test", eventsourcingdb.ReadEventsOptions{Recursive: true}, []int{1, 2, 3, 4}},
+			{"from the root", "/", eventsourcingdb.ReadEventsOptions{Recursive: true}, []int{1, 2, 3, 4, 5}},
+			{
+				"antichronologically",
+				"/test",
+				eventsourcingdb.ReadEventsOptions{Order: eventsourcingdb.OrderAntichronological()},
+				[]int{4, 3, 1},
+			},
+			{
+				"with bounds",
+				"/test",
+				eventsourcingdb.ReadEventsOptions{
+					Recursive:  true,
+					LowerBound: &eventsourcingdb.Bound{ID: "0", Type: eventsourcingdb.BoundTypeExclusive},
+					UpperBound: &eventsourcingdb.Bound{ID: "2", Type: eventsourcingdb.BoundTypeInclusive},
+				},
+				[]int{2, 3},
+			},
+			{
+				"from the latest event",
+				"/test",
+				eventsourcingdb.ReadEventsOptions{
+					FromLatestEvent: &eventsourcingdb.ReadFromLatestEvent{
+						Subject:          "/test",
+						Type:             "io.eventsourcingdb.test.bar",
+						IfEventIsMissing: eventsourcingdb.ReadNothingIfEventIsMissing,
+					},
+				},
+				[]int{3, 4},
+			},
+			{
+				"nothing if the latest event is missing",
+				"/test",
+				eventsourcingdb.ReadEventsOptions{
+					FromLatestEvent: &eventsourcingdb.ReadFromLatestEvent{
+						Subject:          "/test",
+						Type:             "io.eventsourcingdb.test.baz",
+						IfEventIsMissing: eventsourcingdb.ReadNothingIfEventIsMissing,
+					},
+				},
+				[]int{},
+			},
+			{
+				"everything if the latest event is missing",
+				"/test",
+				eventsourcingdb.ReadEventsOptions{
+					FromLatestEvent: &eventsourcingdb.ReadFromLatestEvent{
+						Subject:          "/test",
+						Type:             "io.eventsourcingdb.test.baz",
+						IfEventIsMissing: eventsourcingdb.ReadEverythingIfEventIsMissing,
+					},
+				},
+				[]int{1, 3, 4},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				events := readEvents(t, client, testCase.subject, testCase.options)
+				assert.Equal(t, testCase.expected, getValues(t, events))
+			})
+		}
+	})
+
+	t.Run("observes existing and new events", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer().WithHeartbeatInterval(10*time.Millisecond))
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test.foo", 1),
+			newEvent("/test", "io.eventsourcingdb.test.bar", 2),
+		}, nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		time.AfterFunc(50*time.Millisecond, func() {
+			_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEvent("/other", "io.eventsourcingdb.test.foo", 3),
+				newEvent("/test", "io.eventsourcingdb.test.foo", 4),
+			}, nil)
+			assert.NoError(t, err)
+		})
+
+		observedEvents := []eventsourcingdb.Event{}
+		for event, err := range client.ObserveEvents(ctx, "/test", eventsourcingdb.ObserveEventsOptions{
+			LowerBound: &eventsourcingdb.Bound{ID: "0", Type: eventsourcingdb.BoundTypeExclusive},
+		}) {
+			require.NoError(t, err)
+			observedEvents = append(observedEvents, event)
+
+			if len(observedEvents) == 2 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []int{2, 4}, getValues(t, observedEvents))
+	})
+
+	t.Run("waits for the latest event while observing", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test.foo", 1),
+		}, nil)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		time.AfterFunc(50*time.Millisecond, func() {
+			_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+				newEvent("/test", "io.eventsourcingdb.test.foo", 2),
+				newEvent("/test", "io.eventsourcingdb.test.bar", 3),
+				newEvent("/test", "io.eventsourcingdb.test.foo", 4),
+			}, nil)
+			assert.NoError(t, err)
+		})
+
+		observedEvents := []eventsourcingdb.Event{}
+		for event, err := range client.ObserveEvents(ctx, "/test", eventsourcingdb.ObserveEventsOptions{
+			FromLatestEvent: &eventsourcingdb.ObserveFromLatestEvent{
+				Subject:          "/test",
+				Type:             "io.eventsourcingdb.test.bar",
+				IfEventIsMissing: eventsourcingdb.WaitForEventIfEventIsMissing,
+			},
+		}) {
+			require.NoError(t, err)
+			observedEvents = append(observedEvents, event)
+
+			if len(observedEvents) == 2 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, []int{3, 4}, getValues(t, observedEvents))
+	})
+
+	t.Run("reads subjects", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		_, err := client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test/1", "io.eventsourcingdb.test", 1),
+			newEvent("/test/2", "io.eventsourcingdb.test", 2),
+			newEvent("/other", "io.eventsourcingdb.test", 3),
+		}, nil)
+		require.NoError(t, err)
+
+		subjects := []string{}
+		for subject, err := range client.ReadSubjects(context.Background(), "/test") {
+			require.NoError(t, err)
+			subjects = append(subjects, subject)
+		}
+
+		assert.Equal(t, []string{"/test", "/test/1", "/test/2"}, subjects)
+	})
+
+	t.Run("manages event types and schemas", func(t *testing.T) {
+		client := startServer(t, eventsourcingdbtest.NewServer())
+
+		schema := map[string]any{
+			"type": "object",
+		}
+
+		err := client.RegisterEventSchema("io.eventsourcingdb.test.foo", schema)
+		require.NoError(t, err)
+
+		err = client.RegisterEventSchema("io.eventsourcingdb.test.foo", schema)
+		assert.EqualError(t, err, "failed to register event schema, got HTTP status code '409', expected '200'")
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test.bar", 1),
+		}, nil)
+		require.NoError(t, err)
+
+		eventTypes := []eventsourcingdb.EventType{}
+		for eventType, err := range client.ReadEventTypes(context.Background()) {
+			require.NoError(t, err)
+			eventTypes = append(eventTypes, eventType)
+		}
+
+		assert.Equal(t, []eventsourcingdb.EventType{
+			{EventType: "io.eventsourcingdb.test.bar", IsPhantom: false},
+			{EventType: "io.eventsourcingdb.test.foo", IsPhantom: true, Schema: &schema},
+		}, eventTypes)
+
+		eventType, err := client.ReadEventType("io.eventsourcingdb.test.foo")
+		require.NoError(t, err)
+		assert.Equal(t, &schema, eventType.Schema)
+
+		_, err = client.ReadEventType("io.eventsourcingdb.test.nonexistent")
+		assert.EqualError(t, err, "failed to read event type, got HTTP status code '404', expected '200'")
+
+		_, err = client.ReadEventType("io.eventsourcingdb.test.")
+		assert.EqualError(t, err, "failed to read event type, got HTTP status code '400', expected '200'")
+	})
+
+	t.Run("accepts compressed requests", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer()
+		startServer(t, server)
+
+		client, err := server.GetClient(eventsourcingdb.WithRequestCompression())
+		require.NoError(t, err)
+
+		_, err = client.WriteEvents([]eventsourcingdb.EventCandidate{
+			newEvent("/test", "io.eventsourcingdb.test", 23),
+		}, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("terminates running streams when stopped", func(t *testing.T) {
+		server := eventsourcingdbtest.NewServer()
+		client := startServer(t, server)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for range client.ObserveEvents(context.Background(), "/", eventsourcingdb.ObserveEventsOptions{Recursive: true}) {
+			}
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		err := server.Stop()
+		require.NoError(t, err)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("stream is still running after stopping the server")
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package eventsourcingdbtest
+
+import (
+	"sync"
+	"testing"
+)
+
+// NewTestLogHandler returns a handler for Container.WithLogHandler that writes
+// the logs of a container to the given test, which shows them if the test
+// fails, or if it is run in verbose mode. Once the test has finished, logging
+// to it panics, so logs are dropped from then on.
+func NewTestLogHandler(t testing.TB) func(line string) {
+	var mutex sync.Mutex
+	isFinished := false
+
+	t.Cleanup(func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		isFinished = true
+	})
+
+	return func(line string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if isFinished {
+			return
+		}
+
+		t.Log(line)
+	}
+}
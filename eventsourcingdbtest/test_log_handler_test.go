@@ -0,0 +1,23 @@
+package eventsourcingdbtest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/eventsourcingdbtest"
+)
+
+func TestNewTestLogHandler(t *testing.T) {
+	t.Run("drops logs once the test has finished", func(t *testing.T) {
+		var handler func(line string)
+
+		t.Run("inner", func(t *testing.T) {
+			handler = eventsourcingdbtest.NewTestLogHandler(t)
+			handler("logged while the test is running")
+		})
+
+		assert.NotPanics(t, func() {
+			handler("logged after the test has finished")
+		})
+	})
+}
@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// imageRegex skips flags such as --platform=linux/amd64, which may precede
+// the image of a FROM instruction.
+var imageRegex = regexp.MustCompile(`(?m)^FROM\s+(?:--\S+\s+)*([^-\s]\S*)`)
+
+// GetImageFromDockerfile returns the image of the first FROM instruction in
+// the given Dockerfile, e.g. thenativeweb/eventsourcingdb:1.0.0.
+func GetImageFromDockerfile(path string) (string, error) {
+	dataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	matches := imageRegex.FindStringSubmatch(string(dataBytes))
+	if matches == nil {
+		return "", fmt.Errorf("failed to find image in Dockerfile '%s'", path)
+	}
+
+	return matches[1], nil
+}
@@ -0,0 +1,56 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestGetImageFromDockerfile(t *testing.T) {
+	writeDockerfile := func(t *testing.T, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "Dockerfile")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		return path
+	}
+
+	t.Run("returns the image of the first FROM instruction", func(t *testing.T) {
+		path := writeDockerfile(t, "# syntax=docker/dockerfile:1\nFROM thenativeweb/eventsourcingdb:1.2.3 AS base\nFROM alpine:latest\n")
+
+		image, err := internal.GetImageFromDockerfile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "thenativeweb/eventsourcingdb:1.2.3", image)
+	})
+
+	t.Run("skips flags that precede the image", func(t *testing.T) {
+		path := writeDockerfile(t, "FROM --platform=linux/amd64 thenativeweb/eventsourcingdb:1.2.3 AS base\n")
+
+		image, err := internal.GetImageFromDockerfile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "thenativeweb/eventsourcingdb:1.2.3", image)
+	})
+
+	t.Run("returns an error if there is no FROM instruction", func(t *testing.T) {
+		path := writeDockerfile(t, "# empty\n")
+
+		_, err := internal.GetImageFromDockerfile(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetImageVersionFromDockerfile(t *testing.T) {
+	t.Run("returns the version of the image in the repository's Dockerfile", func(t *testing.T) {
+		image, err := internal.GetImageFromDockerfile(filepath.Join("..", "docker", "Dockerfile"))
+		require.NoError(t, err)
+
+		version, err := internal.GetImageVersionFromDockerfile()
+		require.NoError(t, err)
+		assert.Equal(t, "thenativeweb/eventsourcingdb:"+version, image)
+	})
+}
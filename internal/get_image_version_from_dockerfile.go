@@ -2,29 +2,23 @@ package internal
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
 )
 
-var versionRegex = regexp.MustCompile(`(?m)^FROM\sthenativeweb\/eventsourcingdb:(.+)$`)
+const imageName = "thenativeweb/eventsourcingdb"
 
 func GetImageVersionFromDockerfile() (string, error) {
 	dockerfile := filepath.Join("..", "docker", "Dockerfile")
-	dataBytes, err := os.ReadFile(dockerfile)
+	image, err := GetImageFromDockerfile(dockerfile)
 	if err != nil {
 		return "", err
 	}
 
-	data := string(dataBytes)
-
-	matches := versionRegex.FindStringSubmatch(data)
-	if matches == nil {
-		return "", fmt.Errorf("failed to find image version in Dockerfile")
-	}
-	if len(matches) != 2 {
-		return "", fmt.Errorf("unexpected format in Dockerfile")
+	version, ok := strings.CutPrefix(image, imageName+":")
+	if !ok || version == "" {
+		return "", fmt.Errorf("unexpected image '%s' in Dockerfile, expected '%s' with a version", image, imageName)
 	}
 
-	return matches[1], nil
+	return version, nil
 }
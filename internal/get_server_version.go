@@ -0,0 +1,18 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+)
+
+func GetServerVersion(response *http.Response, headerName string) (string, error) {
+	err := ValidateServerHeader(response, headerName)
+	if err != nil {
+		return "", err
+	}
+
+	serverHeader := response.Header.Get(headerName)
+	version := strings.TrimPrefix(serverHeader, "EventSourcingDB/")
+
+	return version, nil
+}
@@ -0,0 +1,4 @@
+package internal
+
+// InitialPredecessorHash is the predecessor hash of the very first event.
+const InitialPredecessorHash = "0000000000000000000000000000000000000000000000000000000000000000"
@@ -0,0 +1,16 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// NewJSONEncoder returns an encoder that does not escape HTML characters. The
+// data of events must be written exactly as it was hashed, since escaping it
+// would change the hash.
+func NewJSONEncoder(writer io.Writer) *json.Encoder {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	return encoder
+}
@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"context"
+)
+
+type Semaphore struct {
+	slots chan struct{}
+}
+
+func NewSemaphore(size int) *Semaphore {
+	return &Semaphore{
+		slots: make(chan struct{}, size),
+	}
+}
+
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Semaphore) Release() {
+	<-s.slots
+}
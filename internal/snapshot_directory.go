@@ -0,0 +1,105 @@
+package internal
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// SnapshotDirectory copies the contents of the given directory to a new
+// temporary directory, and returns its path.
+func SnapshotDirectory(path string) (string, error) {
+	snapshotPath, err := os.MkdirTemp("", "esdb-snapshot-*")
+	if err != nil {
+		return "", err
+	}
+
+	err = copyDirectory(snapshotPath, path)
+	if err != nil {
+		os.RemoveAll(snapshotPath)
+		return "", err
+	}
+
+	return snapshotPath, nil
+}
+
+// RestoreDirectory replaces the contents of the given directory with the
+// contents of a snapshot. The directory itself is kept, since it may be
+// mounted into a container.
+func RestoreDirectory(snapshotPath string, path string) error {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := os.RemoveAll(filepath.Join(path, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return copyDirectory(path, snapshotPath)
+}
+
+// copyDirectory copies the contents of the source directory to the target
+// directory. Unlike os.CopyFS, it creates directories with mode 0755 and files
+// with mode 0644, regardless of the umask.
+func copyDirectory(targetPath string, sourcePath string) error {
+	return filepath.WalkDir(sourcePath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(sourcePath, path)
+		if err != nil {
+			return err
+		}
+		if relativePath == "." {
+			return nil
+		}
+		targetFilePath := filepath.Join(targetPath, relativePath)
+
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return &fs.PathError{Op: "copy", Path: path, Err: fs.ErrInvalid}
+		}
+
+		if entry.IsDir() {
+			err := os.MkdirAll(targetFilePath, 0755)
+			if err != nil {
+				return err
+			}
+
+			return os.Chmod(targetFilePath, 0755)
+		}
+
+		return copyFile(targetFilePath, path)
+	})
+}
+
+func copyFile(targetPath string, sourcePath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(target, source)
+	if err != nil {
+		target.Close()
+		return err
+	}
+
+	err = target.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(targetPath, 0644)
+}
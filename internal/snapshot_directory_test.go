@@ -0,0 +1,52 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestSnapshotDirectory(t *testing.T) {
+	t.Run("restores the contents of a directory from a snapshot", func(t *testing.T) {
+		path := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(path, "journal"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "journal", "events"), []byte("before"), 0600))
+
+		snapshotPath, err := internal.SnapshotDirectory(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(snapshotPath) })
+
+		require.NoError(t, os.WriteFile(filepath.Join(path, "journal", "events"), []byte("after"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "added"), []byte("added"), 0600))
+
+		err = internal.RestoreDirectory(snapshotPath, path)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(path, "journal", "events"))
+		require.NoError(t, err)
+		assert.Equal(t, "before", string(content))
+		assert.NoFileExists(t, filepath.Join(path, "added"))
+	})
+
+	t.Run("uses fixed modes for copied files and directories", func(t *testing.T) {
+		path := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(path, "journal"), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "journal", "events"), []byte("events"), 0777))
+
+		snapshotPath, err := internal.SnapshotDirectory(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(snapshotPath) })
+
+		directoryInfo, err := os.Stat(filepath.Join(snapshotPath, "journal"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), directoryInfo.Mode().Perm())
+
+		fileInfo, err := os.Stat(filepath.Join(snapshotPath, "journal", "events"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0644), fileInfo.Mode().Perm())
+	})
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// computeHash computes the hash of an event the same way EventSourcingDB does,
+// so that the hashes can be verified with Event.VerifyHash.
+func computeHash(event internal.CloudEvent) string {
+	metadata := fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s|%s",
+		event.SpecVersion,
+		event.ID,
+		event.PredecessorHash,
+		event.Time,
+		event.Source,
+		event.Subject,
+		event.Type,
+		event.DataContentType,
+	)
+
+	metadataHash := sha256.Sum256([]byte(metadata))
+	dataHash := sha256.Sum256(event.Data)
+
+	finalHash := sha256.Sum256(fmt.Appendf(nil, "%x%x", metadataHash, dataHash))
+
+	return fmt.Sprintf("%x", finalHash)
+}
@@ -0,0 +1,14 @@
+package store
+
+import (
+	"encoding/json"
+)
+
+type EventCandidate struct {
+	Source      string          `json:"source"`
+	Subject     string          `json:"subject"`
+	Type        string          `json:"type"`
+	Data        json.RawMessage `json:"data"`
+	TraceParent *string         `json:"traceParent,omitempty"`
+	TraceState  *string         `json:"traceState,omitempty"`
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func (s *Store) RegisterEventSchema(eventType string, schema map[string]any) error {
+	err := validateEventType(eventType)
+	if err != nil {
+		return err
+	}
+	if schema == nil {
+		return fmt.Errorf("%w: schema must not be empty", ErrInvalidRequest)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.schemas[eventType]; ok {
+		return fmt.Errorf("%w: schema for event type '%s' is already registered", ErrConflict, eventType)
+	}
+
+	if s.journal != nil {
+		err := s.journal.AppendEventSchema(eventType, schema)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.schemas[eventType] = schema
+
+	return nil
+}
+
+func (s *Store) ReadEventTypes() []internal.StreamEventType {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	// An event type that has a schema but no events yet is a phantom.
+	isPhantom := map[string]bool{}
+	for eventType := range s.schemas {
+		isPhantom[eventType] = true
+	}
+	for _, event := range s.events {
+		isPhantom[event.Type] = false
+	}
+
+	eventTypes := make([]internal.StreamEventType, 0, len(isPhantom))
+	for _, eventType := range slices.Sorted(maps.Keys(isPhantom)) {
+		eventTypes = append(eventTypes, s.getEventType(eventType, isPhantom[eventType]))
+	}
+
+	return eventTypes
+}
+
+func (s *Store) ReadEventType(eventType string) (internal.StreamEventType, error) {
+	err := validateEventType(eventType)
+	if err != nil {
+		return internal.StreamEventType{}, err
+	}
+
+	for _, candidate := range s.ReadEventTypes() {
+		if candidate.EventType == eventType {
+			return candidate, nil
+		}
+	}
+
+	return internal.StreamEventType{}, fmt.Errorf("%w: event type '%s' does not exist", ErrNotFound, eventType)
+}
+
+// getEventType must be called with the mutex held.
+func (s *Store) getEventType(eventType string, isPhantom bool) internal.StreamEventType {
+	streamEventType := internal.StreamEventType{
+		EventType: eventType,
+		IsPhantom: isPhantom,
+	}
+	if schema, ok := s.schemas[eventType]; ok {
+		streamEventType.Schema = &schema
+	}
+
+	return streamEventType
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+// Journal persists the changes to a store. The store calls it before applying
+// a change, so if the journal fails, the change is rejected.
+type Journal interface {
+	AppendEvents(events []internal.CloudEvent) error
+	AppendEventSchema(eventType string, schema map[string]any) error
+}
+
+func (s *Store) SetJournal(journal Journal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.journal = journal
+}
+
+// LoadEvent adds a previously written event, e.g. when restoring a store from
+// its journal. The event must continue the hash chain, and its hash must be
+// valid, otherwise it is rejected.
+func (s *Store) LoadEvent(event internal.CloudEvent) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expectedID := strconv.Itoa(len(s.events))
+	if event.ID != expectedID {
+		return fmt.Errorf("%w: expected event ID '%s', got '%s'", ErrInvalidRequest, expectedID, event.ID)
+	}
+
+	expectedPredecessorHash := internal.InitialPredecessorHash
+	if len(s.events) > 0 {
+		expectedPredecessorHash = s.events[len(s.events)-1].Hash
+	}
+	if event.PredecessorHash != expectedPredecessorHash {
+		return fmt.Errorf("%w: event '%s' does not continue the hash chain", ErrInvalidRequest, event.ID)
+	}
+	if computeHash(event) != event.Hash {
+		return fmt.Errorf("%w: hash of event '%s' is invalid", ErrInvalidRequest, event.ID)
+	}
+
+	s.events = append(s.events, event)
+	s.notify()
+
+	return nil
+}
+
+func (s *Store) LoadEventSchema(eventType string, schema map[string]any) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.schemas[eventType]; ok {
+		return fmt.Errorf("%w: schema for event type '%s' is already registered", ErrConflict, eventType)
+	}
+
+	s.schemas[eventType] = schema
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package store
+
+type PreconditionPayload struct {
+	Subject string `json:"subject"`
+	EventID string `json:"eventId"`
+	Query   string `json:"query"`
+}
+
+type Precondition struct {
+	Type    string              `json:"type"`
+	Payload PreconditionPayload `json:"payload"`
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func (s *Store) ReadEvents(subject string, options ReadEventsOptions) ([]internal.CloudEvent, error) {
+	err := validateSubject(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	lowerBound, err := getBoundID(options.LowerBound, 1)
+	if err != nil {
+		return nil, err
+	}
+	upperBound, err := getBoundID(options.UpperBound, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch options.Order {
+	case "", "chronological", "antichronological":
+	default:
+		return nil, fmt.Errorf("%w: unsupported order '%s'", ErrInvalidRequest, options.Order)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if options.FromLatestEvent != nil {
+		if options.LowerBound != nil {
+			return nil, fmt.Errorf("%w: lower bound and from latest event are mutually exclusive", ErrInvalidRequest)
+		}
+
+		latestEvent := s.getLatestEvent(options.FromLatestEvent.Subject, options.FromLatestEvent.Type)
+		switch {
+		case latestEvent != nil:
+			lowerBound, _ = strconv.Atoi(latestEvent.ID)
+		case options.FromLatestEvent.IfEventIsMissing == "read-everything":
+		case options.FromLatestEvent.IfEventIsMissing == "read-nothing",
+			options.FromLatestEvent.IfEventIsMissing == "wait-for-event":
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("%w: unsupported if event is missing '%s'", ErrInvalidRequest, options.FromLatestEvent.IfEventIsMissing)
+		}
+	}
+
+	events := []internal.CloudEvent{}
+	for _, event := range s.events {
+		id, _ := strconv.Atoi(event.ID)
+		if id < lowerBound || (upperBound >= 0 && id > upperBound) {
+			continue
+		}
+		if !isSubjectMatching(event.Subject, subject, options.Recursive) {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	if options.Order == "antichronological" {
+		slices.Reverse(events)
+	}
+
+	return events, nil
+}
+
+// getBoundID returns the smallest (for direction 1) or largest (for direction
+// -1) ID that is within the bound. Without a bound, it returns 0 and -1,
+// respectively.
+func getBoundID(bound *Bound, direction int) (int, error) {
+	if bound == nil {
+		if direction > 0 {
+			return 0, nil
+		}
+		return -1, nil
+	}
+
+	id, err := parseEventID(bound.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	switch bound.Type {
+	case "inclusive":
+		return id, nil
+	case "exclusive":
+		return id + direction, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported bound type '%s'", ErrInvalidRequest, bound.Type)
+	}
+}
+
+func isSubjectMatching(subject string, requestedSubject string, recursive bool) bool {
+	if subject == requestedSubject {
+		return true
+	}
+	if !recursive {
+		return false
+	}
+	if requestedSubject == "/" {
+		return true
+	}
+
+	return strings.HasPrefix(subject, requestedSubject+"/")
+}
@@ -0,0 +1,20 @@
+package store
+
+type Bound struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type FromLatestEvent struct {
+	Subject          string `json:"subject"`
+	Type             string `json:"type"`
+	IfEventIsMissing string `json:"ifEventIsMissing"`
+}
+
+type ReadEventsOptions struct {
+	Recursive       bool             `json:"recursive"`
+	Order           string           `json:"order,omitempty"`
+	LowerBound      *Bound           `json:"lowerBound,omitempty"`
+	UpperBound      *Bound           `json:"upperBound,omitempty"`
+	FromLatestEvent *FromLatestEvent `json:"fromLatestEvent,omitempty"`
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"path"
+	"slices"
+)
+
+func (s *Store) ReadSubjects(baseSubject string) ([]string, error) {
+	err := validateSubject(baseSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	// Every event implicitly creates all the subjects above its own one, e.g.
+	// an event for /books/42 also creates /books and /.
+	subjects := map[string]struct{}{}
+	for _, event := range s.events {
+		for subject := event.Subject; ; subject = path.Dir(subject) {
+			subjects[subject] = struct{}{}
+			if subject == "/" {
+				break
+			}
+		}
+	}
+
+	result := []string{}
+	for subject := range subjects {
+		if isSubjectMatching(subject, baseSubject, true) {
+			result = append(result, subject)
+		}
+	}
+	slices.Sort(result)
+
+	return result, nil
+}
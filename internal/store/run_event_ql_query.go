@@ -0,0 +1,23 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+func (s *Store) RunEventQLQuery(query string) ([]json.RawMessage, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.queryEvaluator == nil {
+		return nil, fmt.Errorf("%w: EventQL requires a query evaluator", ErrNotSupported)
+	}
+
+	rows, err := s.queryEvaluator(query, slices.Clone(s.events))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	return rows, nil
+}
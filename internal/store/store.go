@@ -0,0 +1,73 @@
+package store
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+var (
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrPreconditionFailed = errors.New("precondition failed")
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrNotSupported       = errors.New("not supported")
+)
+
+// QueryEvaluator evaluates an EventQL query against the given events, and
+// returns the resulting rows. The store does not understand EventQL itself,
+// so running queries requires an evaluator to be set.
+type QueryEvaluator func(query string, events []internal.CloudEvent) ([]json.RawMessage, error)
+
+// Store keeps events and event schemas in memory, and implements the semantics
+// of the EventSourcingDB API on top of them, e.g. preconditions, bounds, and
+// the hash chain. It is safe for concurrent use.
+type Store struct {
+	mutex          sync.RWMutex
+	events         []internal.CloudEvent
+	schemas        map[string]map[string]any
+	changed        chan struct{}
+	queryEvaluator QueryEvaluator
+	signingKey     ed25519.PrivateKey
+	journal        Journal
+}
+
+func New() *Store {
+	return &Store{
+		schemas: map[string]map[string]any{},
+		changed: make(chan struct{}),
+	}
+}
+
+func (s *Store) SetQueryEvaluator(queryEvaluator QueryEvaluator) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.queryEvaluator = queryEvaluator
+}
+
+func (s *Store) SetSigningKey(signingKey ed25519.PrivateKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.signingKey = signingKey
+}
+
+// Watch returns a channel that is closed as soon as events are written. To not
+// miss any events, call Watch before reading, and read again once the channel
+// is closed.
+func (s *Store) Watch() <-chan struct{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.changed
+}
+
+// notify must be called with the mutex held for writing.
+func (s *Store) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	subjectPattern   = regexp.MustCompile(`^/([^/\s]+(/[^/\s]+)*)?$`)
+	eventTypePattern = regexp.MustCompile(`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+$`)
+)
+
+func validateSubject(subject string) error {
+	if !subjectPattern.MatchString(subject) {
+		return fmt.Errorf("%w: malformed subject '%s'", ErrInvalidRequest, subject)
+	}
+
+	return nil
+}
+
+func validateEventType(eventType string) error {
+	if !eventTypePattern.MatchString(eventType) {
+		return fmt.Errorf("%w: malformed event type '%s'", ErrInvalidRequest, eventType)
+	}
+
+	return nil
+}
+
+func parseEventID(eventID string) (int, error) {
+	id, err := strconv.Atoi(eventID)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: malformed event ID '%s'", ErrInvalidRequest, eventID)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,165 @@
+package store
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func (s *Store) WriteEvents(candidates []EventCandidate, preconditions []Precondition) ([]internal.CloudEvent, error) {
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: events must not be empty", ErrInvalidRequest)
+	}
+
+	data := make([]json.RawMessage, 0, len(candidates))
+	for _, candidate := range candidates {
+		compactData, err := validateEventCandidate(candidate)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, compactData)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, precondition := range preconditions {
+		err := s.checkPrecondition(precondition)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	predecessorHash := internal.InitialPredecessorHash
+	if len(s.events) > 0 {
+		predecessorHash = s.events[len(s.events)-1].Hash
+	}
+
+	writtenEvents := make([]internal.CloudEvent, 0, len(candidates))
+	for i, candidate := range candidates {
+		event := internal.CloudEvent{
+			SpecVersion:     "1.0",
+			ID:              strconv.Itoa(len(s.events) + i),
+			Time:            time.Now().UTC().Format(time.RFC3339Nano),
+			Source:          candidate.Source,
+			Subject:         candidate.Subject,
+			Type:            candidate.Type,
+			DataContentType: "application/json",
+			Data:            data[i],
+			PredecessorHash: predecessorHash,
+			TraceParent:     candidate.TraceParent,
+			TraceState:      candidate.TraceState,
+		}
+		event.Hash = computeHash(event)
+
+		if s.signingKey != nil {
+			signature := "esdb:signature:v1:" + hex.EncodeToString(ed25519.Sign(s.signingKey, []byte(event.Hash)))
+			event.Signature = &signature
+		}
+
+		writtenEvents = append(writtenEvents, event)
+		predecessorHash = event.Hash
+	}
+
+	if s.journal != nil {
+		err := s.journal.AppendEvents(writtenEvents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.events = append(s.events, writtenEvents...)
+	s.notify()
+
+	return writtenEvents, nil
+}
+
+// validateEventCandidate validates the candidate, and returns its data in
+// compact form, since this is the form the hash is computed from.
+func validateEventCandidate(candidate EventCandidate) (json.RawMessage, error) {
+	if candidate.Source == "" {
+		return nil, fmt.Errorf("%w: source must not be empty", ErrInvalidRequest)
+	}
+
+	err := validateSubject(candidate.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateEventType(candidate.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	var compactData bytes.Buffer
+	err = json.Compact(&compactData, candidate.Data)
+	if err != nil || !bytes.HasPrefix(compactData.Bytes(), []byte("{")) {
+		return nil, fmt.Errorf("%w: data must be a JSON object", ErrInvalidRequest)
+	}
+
+	return compactData.Bytes(), nil
+}
+
+// checkPrecondition must be called with the mutex held.
+func (s *Store) checkPrecondition(precondition Precondition) error {
+	switch precondition.Type {
+	case "isSubjectPristine":
+		if s.getLatestEvent(precondition.Payload.Subject, "") != nil {
+			return fmt.Errorf("%w: subject '%s' is not pristine", ErrPreconditionFailed, precondition.Payload.Subject)
+		}
+	case "isSubjectPopulated":
+		if s.getLatestEvent(precondition.Payload.Subject, "") == nil {
+			return fmt.Errorf("%w: subject '%s' is not populated", ErrPreconditionFailed, precondition.Payload.Subject)
+		}
+	case "isSubjectOnEventId":
+		latestEvent := s.getLatestEvent(precondition.Payload.Subject, "")
+		if latestEvent == nil || latestEvent.ID != precondition.Payload.EventID {
+			return fmt.Errorf("%w: subject '%s' is not on event '%s'", ErrPreconditionFailed, precondition.Payload.Subject, precondition.Payload.EventID)
+		}
+	case "isEventQlQueryTrue":
+		if s.queryEvaluator == nil {
+			return fmt.Errorf("%w: EventQL requires a query evaluator", ErrNotSupported)
+		}
+
+		rows, err := s.queryEvaluator(precondition.Payload.Query, slices.Clone(s.events))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+		}
+
+		var isTrue bool
+		if len(rows) > 0 {
+			_ = json.Unmarshal(rows[0], &isTrue)
+		}
+		if !isTrue {
+			return fmt.Errorf("%w: query '%s' is not true", ErrPreconditionFailed, precondition.Payload.Query)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported precondition type '%s'", ErrInvalidRequest, precondition.Type)
+	}
+
+	return nil
+}
+
+// getLatestEvent returns the latest event of the given subject, optionally of
+// the given type. It must be called with the mutex held.
+func (s *Store) getLatestEvent(subject string, eventType string) *internal.CloudEvent {
+	for i := len(s.events) - 1; i >= 0; i-- {
+		if s.events[i].Subject != subject {
+			continue
+		}
+		if eventType != "" && s.events[i].Type != eventType {
+			continue
+		}
+
+		return &s.events[i]
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket limits the rate of operations. It holds up to burst tokens and
+// refills them continuously at the given rate per second. Every operation
+// takes one token, and waits if none is left.
+type TokenBucket struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mutex.Lock()
+
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.lastRefill).Seconds()*b.rate)
+	b.lastRefill = now
+
+	// The token is taken right away, even if this means going into debt.
+	// This way, waiting callers are served in the order they arrived,
+	// since every caller waits until its own debt is paid off.
+	b.tokens--
+	if b.tokens >= 0 {
+		b.mutex.Unlock()
+		return nil
+	}
+
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mutex.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// The caller gives up, so its token is handed back, to not slow
+		// down those that are still waiting.
+		b.Return()
+
+		return ctx.Err()
+	}
+}
+
+// Return hands back a token that was taken by Wait, but not used, e.g.
+// because the operation was given up for another reason.
+func (b *TokenBucket) Return() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
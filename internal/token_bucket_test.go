@@ -0,0 +1,68 @@
+package internal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thenativeweb/eventsourcingdb-client-golang/internal"
+)
+
+func TestTokenBucket(t *testing.T) {
+	t.Run("allows a burst without waiting.", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenBucket := internal.NewTokenBucket(1, 3)
+
+		start := time.Now()
+		for range 3 {
+			err := tokenBucket.Wait(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("waits for tokens to be refilled.", func(t *testing.T) {
+		ctx := context.Background()
+
+		tokenBucket := internal.NewTokenBucket(20, 1)
+
+		start := time.Now()
+		for range 3 {
+			err := tokenBucket.Wait(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("stops waiting when the context is canceled.", func(t *testing.T) {
+		tokenBucket := internal.NewTokenBucket(0.1, 1)
+
+		err := tokenBucket.Wait(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err = tokenBucket.Wait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("hands back a returned token.", func(t *testing.T) {
+		tokenBucket := internal.NewTokenBucket(0.1, 1)
+
+		err := tokenBucket.Wait(context.Background())
+		require.NoError(t, err)
+		tokenBucket.Return()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err = tokenBucket.Wait(ctx)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// EventCandidate is the file format of event candidates, e.g. for seed files
+// or the write command of the CLI. Its fields are named like the ones of
+// CloudEvent.
+type EventCandidate struct {
+	Source      string          `json:"source"`
+	Subject     string          `json:"subject"`
+	Type        string          `json:"type"`
+	Data        json.RawMessage `json:"data"`
+	TraceParent *string         `json:"traceparent,omitempty"`
+	TraceState  *string         `json:"tracestate,omitempty"`
+}
+
+// UnmarshalEventCandidates parses either a JSON array of event candidates, or
+// one event candidate per line (NDJSON). Unknown fields are rejected, so that
+// typos do not go unnoticed.
+func UnmarshalEventCandidates(content []byte) ([]EventCandidate, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var eventCandidates []EventCandidate
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("[")) {
+		err := decoder.Decode(&eventCandidates)
+		if err != nil {
+			return nil, err
+		}
+
+		return eventCandidates, nil
+	}
+
+	for {
+		var eventCandidate EventCandidate
+		err := decoder.Decode(&eventCandidate)
+		if errors.Is(err, io.EOF) {
+			return eventCandidates, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		eventCandidates = append(eventCandidates, eventCandidate)
+	}
+}
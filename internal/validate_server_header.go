@@ -6,8 +6,8 @@ import (
 	"strings"
 )
 
-func ValidateServerHeader(response *http.Response) error {
-	serverHeader := response.Header.Get("Server")
+func ValidateServerHeader(response *http.Response, headerName string) error {
+	serverHeader := response.Header.Get(headerName)
 
 	if serverHeader == "" || !strings.HasPrefix(serverHeader, "EventSourcingDB/") {
 		return errors.New("server must be EventSourcingDB")